@@ -0,0 +1,75 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// MoonPhaseValue represents a moon phase description at a given time.
+type MoonPhaseValue struct {
+	Text  string    `json:"text,omitempty"`
+	Time  time.Time `json:"time,omitempty"`
+	Value float64   `json:"value,omitempty"`
+}
+
+// MoonPhase represents the current and the closest moon phase of a day.
+type MoonPhase struct {
+	Closest MoonPhaseValue `json:"closest,omitempty"`
+	Current MoonPhaseValue `json:"current,omitempty"`
+}
+
+// AstronomyDay represents the astronomy data of a single day. Rise, set, dawn and
+// dusk times are nil when the event does not occur on that day, e.g. during polar day.
+type AstronomyDay struct {
+	AstronomicalDawn *time.Time `json:"astronomicalDawn,omitempty"`
+	AstronomicalDusk *time.Time `json:"astronomicalDusk,omitempty"`
+	CivilDawn        *time.Time `json:"civilDawn,omitempty"`
+	CivilDusk        *time.Time `json:"civilDusk,omitempty"`
+	MoonFraction     float64    `json:"moonFraction,omitempty"`
+	MoonPhase        MoonPhase  `json:"moonPhase,omitempty"`
+	Moonrise         *time.Time `json:"moonrise,omitempty"`
+	Moonset          *time.Time `json:"moonset,omitempty"`
+	NauticalDawn     *time.Time `json:"nauticalDawn,omitempty"`
+	NauticalDusk     *time.Time `json:"nauticalDusk,omitempty"`
+	Sunrise          *time.Time `json:"sunrise,omitempty"`
+	Sunset           *time.Time `json:"sunset,omitempty"`
+	Time             time.Time  `json:"time,omitempty"`
+}
+
+// AstronomyPoints represents the astronomy point request response.
+type AstronomyPoints struct {
+	Data []AstronomyDay `json:"data,omitempty"`
+	Meta Meta           `json:"meta,omitempty"`
+}
+
+// AstronomyPointRequestOptions represents the options for the astronomy point request.
+type AstronomyPointRequestOptions struct {
+	CommonRequestOptions
+}
+
+// GetAstronomyPoint sends an astronomy point request: https://docs.stormglass.io/#/astronomy?id=point-request
+func (c *Client) GetAstronomyPoint(
+	ctx context.Context, options AstronomyPointRequestOptions,
+) (*AstronomyPoints, error) {
+	values := url.Values{}
+	values.Add("lat", fmt.Sprintf("%f", options.Lat))
+	values.Add("lng", fmt.Sprintf("%f", options.Lng))
+
+	if options.Start != nil {
+		values.Add("start", fmt.Sprintf("%d", options.Start.Unix()))
+	}
+
+	if options.End != nil {
+		values.Add("end", fmt.Sprintf("%d", options.End.Unix()))
+	}
+
+	res := AstronomyPoints{}
+
+	if err := c.get(ctx, EndpointAstronomyPoint, options, values, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
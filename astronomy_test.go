@@ -0,0 +1,121 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetAstronomyPoint(t *testing.T) {
+	var (
+		start        = now.BeginningOfDay()
+		end          = now.EndOfDay()
+		testKey      = "testkey123"
+		endpointPath = "/astronomy/point"
+		lat          = 58.7984
+		lng          = 17.8081
+	)
+
+	t.Run("test full url composition", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Add("lat", fmt.Sprintf("%f", lat))
+			expectedValues.Add("lng", fmt.Sprintf("%f", lng))
+			expectedValues.Add("start", fmt.Sprintf("%d", start.Unix()))
+			expectedValues.Add("end", fmt.Sprintf("%d", end.Unix()))
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			_, _ = fmt.Fprintln(w, "{}")
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetAstronomyPoint(ctx, AstronomyPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat:   lat,
+				Lng:   lng,
+				Start: &start,
+				End:   &end,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+	})
+
+	t.Run("test response decoding", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := `{
+				"data": [{
+					"astronomicalDawn": "2022-06-01T00:23:36+00:00",
+					"astronomicalDusk": null,
+					"civilDawn": "2022-06-01T01:52:10+00:00",
+					"civilDusk": "2022-06-01T20:41:02+00:00",
+					"moonFraction": 0.04,
+					"moonPhase": {
+						"closest": {"text": "New moon", "time": "2022-05-30T11:00:00+00:00", "value": 0},
+						"current": {"text": "Waxing crescent", "time": "2022-06-01T00:00:00+00:00", "value": 0.06}
+					},
+					"moonrise": "2022-06-01T03:12:00+00:00",
+					"moonset": null,
+					"nauticalDawn": "2022-06-01T01:01:00+00:00",
+					"nauticalDusk": "2022-06-01T21:32:00+00:00",
+					"sunrise": "2022-06-01T02:35:21+00:00",
+					"sunset": "2022-06-01T19:58:54+00:00",
+					"time": "2022-06-01T00:00:00+00:00"
+				}],
+				"meta": {"cost": 1}
+			}`
+			if _, err := w.Write([]byte(want)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetAstronomyPoint(ctx, AstronomyPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat: lat,
+				Lng: lng,
+			},
+		})
+
+		require.NoError(t, err, "expecting nil err")
+		require.Len(t, res.Data, 1)
+
+		day := res.Data[0]
+		require.NotNil(t, day.Sunrise)
+		assert.Equal(t, time.Date(2022, 6, 1, 2, 35, 21, 0, time.UTC), day.Sunrise.UTC())
+		assert.Nil(t, day.AstronomicalDusk)
+		assert.Nil(t, day.Moonset)
+		assert.NotNil(t, day.Moonrise)
+		assert.Equal(t, 0.04, day.MoonFraction)
+		assert.Equal(t, "New moon", day.MoonPhase.Closest.Text)
+		assert.Equal(t, "Waxing crescent", day.MoonPhase.Current.Text)
+		assert.Equal(t, 0.06, day.MoonPhase.Current.Value)
+		assert.Equal(t, time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), day.Time.UTC())
+	})
+}
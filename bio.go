@@ -0,0 +1,112 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BioPoints represents a bio point request response.
+type BioPoints struct {
+	Hours []BioHour `json:"hours,omitempty"`
+	Meta  Meta      `json:"meta,omitempty"`
+}
+
+// BioSourcesOptions : https://docs.stormglass.io/#/sources?id=available-sources
+type BioSourcesOptions struct {
+	MeteoFrance bool
+	StormGlass  bool
+}
+
+func (s BioSourcesOptions) toList() []string {
+	var sources []string
+	if s.MeteoFrance {
+		sources = append(sources, "meteo")
+	}
+
+	if s.StormGlass {
+		sources = append(sources, "sg")
+	}
+
+	return sources
+}
+
+// BioSourceValues represents potential bio source values response.
+type BioSourceValues struct {
+	MeteoFrance *float64 `json:"meteo,omitempty"`
+	StormGlass  *float64 `json:"sg,omitempty"`
+}
+
+// BioHour represents an hour bio data point response.
+type BioHour struct {
+	Chlorophyll *BioSourceValues `json:"chlorophyll,omitempty"`
+	Iron        *BioSourceValues `json:"iron,omitempty"`
+	Nitrate     *BioSourceValues `json:"nitrate,omitempty"`
+	Oxygen      *BioSourceValues `json:"oxygen,omitempty"`
+	Ph          *BioSourceValues `json:"ph,omitempty"`
+	Phosphate   *BioSourceValues `json:"phosphate,omitempty"`
+	Phyto       *BioSourceValues `json:"phyto,omitempty"`
+	Salinity    *BioSourceValues `json:"salinity,omitempty"`
+	Silicate    *BioSourceValues `json:"silicate,omitempty"`
+	Time        *time.Time       `json:"time,omitempty"`
+}
+
+// BioParamsOptions holds optional bio parameters.
+type BioParamsOptions struct {
+	Chlorophyll bool
+	Iron        bool
+	Nitrate     bool
+	Oxygen      bool
+	Ph          bool
+	Phosphate   bool
+	Phyto       bool
+	Salinity    bool
+	Silicate    bool
+}
+
+func (p BioParamsOptions) toList() []string {
+	return paramsToList(p)
+}
+
+// BioPointRequestOptions for available bio query parameters.
+type BioPointRequestOptions struct {
+	CommonRequestOptions
+	Params BioParamsOptions  `json:"params,omitempty"`
+	Source BioSourcesOptions `json:"sources,omitempty"`
+}
+
+// GetBioPoint sends a bio point request https://docs.stormglass.io/#/bio?id=point-request.
+func (c *Client) GetBioPoint(ctx context.Context, options BioPointRequestOptions) (*BioPoints, error) {
+	values := url.Values{}
+
+	values.Add("lat", fmt.Sprintf("%f", options.Lat))
+	values.Add("lng", fmt.Sprintf("%f", options.Lng))
+
+	params := options.Params.toList()
+	if len(params) > 0 {
+		values.Add("params", strings.Join(params, ","))
+	}
+
+	if options.Start != nil {
+		values.Add("start", fmt.Sprintf("%d", options.Start.Unix()))
+	}
+
+	if options.End != nil {
+		values.Add("end", fmt.Sprintf("%d", options.End.Unix()))
+	}
+
+	sources := options.Source.toList()
+	if len(sources) > 0 {
+		values.Add("source", strings.Join(sources, ","))
+	}
+
+	res := BioPoints{}
+
+	if err := c.get(ctx, EndpointBioPoint, options, values, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
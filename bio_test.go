@@ -0,0 +1,166 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBioParamOptionsToList(t *testing.T) {
+	t.Run("with no options", func(t *testing.T) {
+		var options BioParamsOptions
+		list := options.toList()
+
+		assert.Len(t, list, 0)
+	})
+
+	t.Run("with all options", func(t *testing.T) {
+		options := BioParamsOptions{}
+
+		v := reflect.ValueOf(&options)
+		e := v.Elem()
+
+		for i := 0; i < e.NumField(); i++ {
+			e.Field(i).SetBool(true)
+		}
+
+		list := options.toList()
+
+		expected := []string{
+			"chlorophyll",
+			"iron",
+			"nitrate",
+			"oxygen",
+			"ph",
+			"phosphate",
+			"phyto",
+			"salinity",
+			"silicate",
+		}
+
+		assert.Equal(t, expected, list)
+	})
+}
+
+func TestBioSourceOptionsToList(t *testing.T) {
+	t.Run("with no options", func(t *testing.T) {
+		var options BioSourcesOptions
+		list := options.toList()
+
+		assert.Len(t, list, 0)
+	})
+
+	t.Run("with all options", func(t *testing.T) {
+		options := BioSourcesOptions{
+			MeteoFrance: true,
+			StormGlass:  true,
+		}
+
+		assert.Equal(t, []string{"meteo", "sg"}, options.toList())
+	})
+}
+
+func TestClient_GetBioPoint(t *testing.T) {
+	var (
+		start        = now.BeginningOfDay()
+		end          = now.EndOfDay()
+		testKey      = "testkey123"
+		endpointPath = "/bio/point"
+		lat          = 58.7984
+		lng          = 17.8081
+	)
+
+	t.Run("test full url composition", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Set("lat", fmt.Sprintf("%f", lat))
+			expectedValues.Set("lng", fmt.Sprintf("%f", lng))
+			expectedValues.Set("start", fmt.Sprintf("%d", start.Unix()))
+			expectedValues.Set("end", fmt.Sprintf("%d", end.Unix()))
+			expectedValues.Set("params", "chlorophyll,ph")
+			expectedValues.Set("source", "sg")
+
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			_, _ = fmt.Fprintln(w, "{}")
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetBioPoint(ctx, BioPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat:   lat,
+				Lng:   lng,
+				Start: &start,
+				End:   &end,
+			},
+			Params: BioParamsOptions{
+				Chlorophyll: true,
+				Ph:          true,
+			},
+			Source: BioSourcesOptions{
+				StormGlass: true,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+	})
+
+	t.Run("test response decoding", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := `{
+				"hours": [
+					{"time": "2022-06-01T00:00:00+00:00", "salinity": {"meteo": 35.1, "sg": 35.2}}
+				],
+				"meta": {"cost": 1, "params": ["salinity"]}
+			}`
+			if _, err := w.Write([]byte(want)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetBioPoint(ctx, BioPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat: lat,
+				Lng: lng,
+			},
+			Params: BioParamsOptions{
+				Salinity: true,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.Len(res.Hours, 1)
+		assertion.NotNil(res.Hours[0].Time)
+		assertion.NotNil(res.Hours[0].Salinity)
+		assertion.Equal(35.1, *res.Hours[0].Salinity.MeteoFrance)
+		assertion.Equal(35.2, *res.Hours[0].Salinity.StormGlass)
+		assertion.Nil(res.Hours[0].Chlorophyll)
+	})
+}
@@ -0,0 +1,215 @@
+package stormglass
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is the time responses are cached for endpoints without a CacheTTL.
+const DefaultCacheTTL = time.Hour
+
+// Cache stores raw response bodies by request key. Implementations must be safe
+// for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+type bypassCacheKey struct{}
+
+// WithoutCache returns a context making requests bypass the client cache. The
+// fresh response still replaces the cached one.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// cacheKey returns the request URL, whose query is sorted, as cache key, empty
+// without a cache, and whether the response is looked up in the cache. The API
+// key is sent in the Authorization header, so it is never part of the cache key.
+func (c *Client) cacheKey(ctx context.Context, u *url.URL) (string, bool) {
+	if c.Cache == nil {
+		return "", false
+	}
+
+	return u.String(), ctx.Value(bypassCacheKey{}) == nil
+}
+
+func (c *Client) cacheTTL(endpoint Endpoint) time.Duration {
+	if ttl, ok := c.CacheTTL[endpoint]; ok {
+		return ttl
+	}
+
+	return DefaultCacheTTL
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in-memory least recently used Cache.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// NewMemoryCache returns a MemoryCache holding at most capacity responses.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached value of the key if not expired.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*memoryCacheEntry) //nolint:errcheck // the list only holds entries
+	if time.Now().After(entry.expires) {
+		m.order.Remove(el)
+		delete(m.entries, key)
+
+		return nil, false
+	}
+
+	m.order.MoveToFront(el)
+
+	return entry.value, true
+}
+
+// Set caches the value of the key, evicting the least recently used value when full.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 || m.capacity <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+
+	if el, ok := m.entries[key]; ok {
+		el.Value = entry
+		m.order.MoveToFront(el)
+
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(entry)
+
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key) //nolint:errcheck // the list only holds entries
+	}
+}
+
+// Len returns the number of cached values.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+type fileCacheEntry struct {
+	Expires time.Time       `json:"expires"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// FileCache is a Cache storing one file per response in a directory, so cached
+// responses survive restarts and can be shared between processes.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a FileCache storing responses in dir, which is created
+// when missing.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+// Get returns the cached value of the key if not expired.
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(f.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry fileCacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Now().After(entry.Expires) {
+		_ = os.Remove(f.path(key))
+
+		return nil, false
+	}
+
+	return entry.Value, true
+}
+
+// Set caches the value of the key. Values which are not valid JSON are not cached.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 || !json.Valid(value) {
+		return
+	}
+
+	data, err := json.Marshal(fileCacheEntry{
+		Expires: time.Now().Add(ttl),
+		Value:   value,
+	})
+	if err != nil {
+		return
+	}
+
+	// write to a temporary file first so readers never see a partial entry.
+	tmp, err := os.CreateTemp(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	if err = os.Rename(tmp.Name(), f.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,196 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("evicts least recently used", func(t *testing.T) {
+		c := NewMemoryCache(2)
+		c.Set("a", []byte("1"), time.Minute)
+		c.Set("b", []byte("2"), time.Minute)
+
+		_, ok := c.Get("a")
+		assert.True(t, ok)
+
+		c.Set("c", []byte("3"), time.Minute)
+		assert.Equal(t, 2, c.Len())
+
+		_, ok = c.Get("b")
+		assert.False(t, ok, "expected b to be evicted")
+
+		v, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), v)
+	})
+
+	t.Run("expires values", func(t *testing.T) {
+		c := NewMemoryCache(2)
+		c.Set("a", []byte("1"), time.Nanosecond)
+		time.Sleep(time.Millisecond)
+
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("replaces values", func(t *testing.T) {
+		c := NewMemoryCache(2)
+		c.Set("a", []byte("1"), time.Minute)
+		c.Set("a", []byte("2"), time.Minute)
+
+		v, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("2"), v)
+		assert.Equal(t, 1, c.Len())
+	})
+}
+
+func TestFileCache(t *testing.T) {
+	t.Run("stores and expires values", func(t *testing.T) {
+		c, err := NewFileCache(t.TempDir())
+		require.NoError(t, err)
+
+		c.Set("a", []byte(`{"a":1}`), time.Minute)
+		c.Set("b", []byte(`{"b":1}`), time.Nanosecond)
+		time.Sleep(time.Millisecond)
+
+		v, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.JSONEq(t, `{"a":1}`, string(v))
+
+		_, ok = c.Get("b")
+		assert.False(t, ok)
+
+		_, ok = c.Get("c")
+		assert.False(t, ok)
+	})
+
+	t.Run("does not store invalid json", func(t *testing.T) {
+		c, err := NewFileCache(t.TempDir())
+		require.NoError(t, err)
+
+		c.Set("a", []byte(`{{`), time.Minute)
+
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+	})
+}
+
+func TestClientCache(t *testing.T) {
+	var testKey = "testkey123"
+
+	newClient := func(calls *int32) (*Client, func()) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(calls, 1)
+			_, _ = w.Write([]byte(`{"data": {"elevation": 12}, "meta": {"cost": 1}}`))
+		}))
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.Cache = NewMemoryCache(10)
+
+		return c, ts.Close
+	}
+
+	t.Run("serves repeated requests from the cache", func(t *testing.T) {
+		var calls int32
+		c, closeServer := newClient(&calls)
+		defer closeServer()
+
+		ctx := context.Background()
+		for i := 0; i < 3; i++ {
+			res, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{Lat: 1, Lng: 2})
+			require.NoError(t, err)
+			assert.Equal(t, 12.0, res.Data.Elevation)
+		}
+
+		_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{Lat: 2, Lng: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		assert.Equal(t, 2, c.Usage().Cost, "expected cached responses to cost nothing")
+	})
+
+	t.Run("bypasses the cache per call", func(t *testing.T) {
+		var calls int32
+		c, closeServer := newClient(&calls)
+		defer closeServer()
+
+		ctx := context.Background()
+		_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+		require.NoError(t, err)
+
+		_, err = c.GetElevationPoint(WithoutCache(ctx), ElevationPointRequestOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("refreshes the cache when bypassed", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&calls, 1)
+			_, _ = fmt.Fprintf(w, `{"data": {"elevation": %d}, "meta": {"cost": 1}}`, n)
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.Cache = NewMemoryCache(10)
+
+		ctx := context.Background()
+		_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+		require.NoError(t, err)
+
+		res, err := c.GetElevationPoint(WithoutCache(ctx), ElevationPointRequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, res.Data.Elevation)
+
+		res, err = c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, res.Data.Elevation, "expected the bypassed response to replace the cached one")
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("uses the ttl per endpoint", func(t *testing.T) {
+		var calls int32
+		c, closeServer := newClient(&calls)
+		defer closeServer()
+
+		c.CacheTTL = map[Endpoint]time.Duration{
+			EndpointElevationPoint: 0,
+		}
+
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("keys responses by request url", func(t *testing.T) {
+		var calls int32
+		c, closeServer := newClient(&calls)
+		defer closeServer()
+
+		_, err := c.GetElevationPoint(context.Background(), ElevationPointRequestOptions{Lat: 1, Lng: 2})
+		require.NoError(t, err)
+
+		_, ok := c.Cache.Get(c.BaseURL + "/elevation/point?lat=1.000000&lng=2.000000")
+		assert.True(t, ok)
+	})
+}
@@ -1,9 +1,12 @@
 package stormglass
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -12,43 +15,238 @@ const (
 	BaseURLV2 = "https://api.stormglass.io/v2"
 )
 
+// Endpoint identifies an API endpoint by its path relative to the base URL.
+type Endpoint string
+
+// Endpoints of the Stormglass API.
+const (
+	EndpointWeatherPoint      Endpoint = "weather/point"
+	EndpointBioPoint          Endpoint = "bio/point"
+	EndpointTideExtremesPoint Endpoint = "tide/extremes/point"
+	EndpointTideSeaLevelPoint Endpoint = "tide/sea-level/point"
+	EndpointTideStations      Endpoint = "tide/sea-level/stations"
+	EndpointTideStationsArea  Endpoint = "tide/sea-level/stations/area"
+	EndpointAstronomyPoint    Endpoint = "astronomy/point"
+	EndpointSolarPoint        Endpoint = "solar/point"
+	EndpointElevationPoint    Endpoint = "elevation/point"
+)
+
 // Client for accessing StormGlass API.
 type Client struct {
 	BaseURL    string
 	apiKey     string
 	HTTPClient *http.Client
+	UserAgent  string
+	Logger     Logger
+
+	// DefaultSources are requested by GetPoint when the request options select none.
+	DefaultSources WeatherSourcesOptions
+
+	// RetryPolicy enables retries of transient failures when set.
+	RetryPolicy *RetryPolicy
+	// RateLimiter is waited on before every request when set.
+	RateLimiter Limiter
+
+	// Cache stores responses when set. CacheTTL overrides DefaultCacheTTL per endpoint.
+	Cache    Cache
+	CacheTTL map[Endpoint]time.Duration
+
+	// Middleware wraps the transport of every request, the first one being the outermost.
+	Middleware []Middleware
+	// Hooks observe every endpoint call.
+	Hooks []Hook
+
+	// UsageThreshold is the used fraction of the daily quota, e.g. 0.9, at which
+	// OnUsageThreshold is called. It is called at most once per day.
+	UsageThreshold   float64
+	OnUsageThreshold func(Usage)
+
+	usage usageLedger
+	err   error
 }
 
-// NewClient returns a new Client with default config.
-func NewClient(apiKey string) *Client {
-	return &Client{
+// NewClient returns a new Client with default config changed by the options.
+// An invalid option is reported by Err and returned by every request.
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
 		BaseURL: BaseURLV2,
 		apiKey:  apiKey,
 		HTTPClient: &http.Client{
 			Timeout: time.Minute,
 		},
 	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			c.err = fmt.Errorf("stormglass: invalid option: %w", err)
+			break
+		}
+	}
+
+	return c
+}
+
+// Err returns the error of the first invalid option passed to NewClient.
+func (c *Client) Err() error {
+	return c.err
+}
+
+// get sends a GET request to the endpoint with the query values and decodes the
+// response into v, going through the client hooks and cache when set.
+func (c *Client) get(
+	ctx context.Context, endpoint Endpoint, options interface{}, values url.Values, v interface{},
+) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	info := RequestInfo{
+		Endpoint: endpoint,
+		Options:  options,
+		Query:    values,
+	}
+
+	ctx = context.WithValue(ctx, endpointKey{}, endpoint)
+	for _, h := range c.Hooks {
+		ctx = h.BeforeRequest(ctx, info)
+	}
+
+	start := time.Now()
+	res, err := c.getResponse(ctx, endpoint, values, v)
+
+	result := ResponseInfo{
+		RequestInfo: info,
+		StatusCode:  res.statusCode,
+		Duration:    time.Since(start),
+		Attempts:    res.attempts,
+		Cached:      res.cached,
+		CacheMiss:   res.cacheMiss,
+		Err:         err,
+	}
+
+	if r, ok := v.(metaResponse); ok && err == nil {
+		m := r.meta()
+		result.Meta = &m
+	}
+
+	for _, h := range c.Hooks {
+		h.AfterResponse(ctx, result)
+	}
+
+	c.logResponse(result)
+
+	return err
+}
+
+func (c *Client) getResponse(
+	ctx context.Context, endpoint Endpoint, values url.Values, v interface{},
+) (response, error) {
+	path, err := url.JoinPath(c.BaseURL, string(endpoint))
+	if err != nil {
+		return response{}, err
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return response{}, err
+	}
+
+	u.RawQuery = values.Encode()
+
+	key, lookup := c.cacheKey(ctx, u)
+	cacheMiss := false
+
+	if lookup {
+		data, ok := c.Cache.Get(key)
+		if ok {
+			res := response{body: data, statusCode: http.StatusOK, cached: true}
+			if err = json.Unmarshal(data, v); err != nil {
+				return res, fmt.Errorf("decode error %w", err)
+			}
+
+			return res, nil
+		}
+
+		cacheMiss = true
+	}
+
+	req, err := http.NewRequest("GET", u.String(), http.NoBody)
+	if err != nil {
+		return response{}, err
+	}
+
+	req = req.WithContext(ctx)
+
+	res, err := c.fetch(req)
+	res.cacheMiss = cacheMiss
+
+	if err != nil {
+		return res, err
+	}
+
+	if err = c.decode(res.body, v); err != nil {
+		return res, err
+	}
+
+	if key != "" {
+		c.Cache.Set(key, res.body, c.cacheTTL(endpoint))
+	}
+
+	return res, nil
 }
 
 func (c *Client) sendRequest(req *http.Request, v interface{}) error {
+	res, err := c.fetch(req)
+	if err != nil {
+		return err
+	}
+
+	return c.decode(res.body, v)
+}
+
+// response is the outcome of a request.
+type response struct {
+	body       []byte
+	statusCode int
+	attempts   int
+	cached     bool
+	cacheMiss  bool
+}
+
+// fetch sends the request and returns the body of a successful response.
+func (c *Client) fetch(req *http.Request) (response, error) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
 	req.Header.Set("Authorization", c.apiKey)
 
-	res, err := c.HTTPClient.Do(req)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	res, attempts, err := c.do(req)
 	if err != nil {
-		return err
+		return response{attempts: attempts}, err
 	}
 
 	defer res.Body.Close()
 
+	result := response{statusCode: res.StatusCode, attempts: attempts}
+
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return NewError(res)
+		return result, NewError(res)
 	}
 
-	if err = json.NewDecoder(res.Body).Decode(v); err != nil {
+	result.body, err = io.ReadAll(res.Body)
+
+	return result, err
+}
+
+func (c *Client) decode(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
 		return fmt.Errorf("decode error %w", err)
 	}
 
+	c.recordUsage(v)
+
 	return nil
 }
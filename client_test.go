@@ -40,6 +40,7 @@ func TestClientSendRequest(t *testing.T) {
 		err := c.sendRequest(req, nil)
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "API key is invalid")
+		assert.ErrorIs(t, err, ErrInvalidKey)
 	})
 	t.Run("test decode error", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,98 @@
+// Command stormglass-proxy serves the Stormglass API paths on a local address
+// and forwards requests to Stormglass through a single Client, so services
+// share its cache, rate limit and daily quota without holding the API key.
+//
+// Usage:
+//
+//	STORMGLASS_API_KEY=... stormglass-proxy -addr 127.0.0.1:8080 -cache-dir /var/cache/stormglass
+//
+// Callers send requests like they would to Stormglass, without a key:
+//
+//	curl 'http://localhost:8080/v2/weather/point?lat=58.7984&lng=17.8081&params=waveHeight'
+//
+// The usage observed for the current day is served at /usage.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+func main() {
+	var (
+		addr      = flag.String("addr", "127.0.0.1:8080", "address to listen on, local only by default")
+		baseURL   = flag.String("base-url", stormglass.BaseURLV2, "base URL of the Stormglass API")
+		cacheSize = flag.Int("cache-size", 1000, "number of responses cached in memory")
+		cacheDir  = flag.String("cache-dir", "", "directory caching responses on disk instead of in memory")
+		cacheTTL  = flag.Duration("cache-ttl", stormglass.DefaultCacheTTL, "time responses are cached")
+		rate      = flag.Float64("rate", 5, "requests per second sent to Stormglass")
+		dailyMax  = flag.Int("daily-limit", 0, "requests per day sent to Stormglass, unlimited when 0")
+	)
+
+	flag.Parse()
+
+	apiKey := os.Getenv("STORMGLASS_API_KEY")
+	if apiKey == "" {
+		log.Fatal("stormglass-proxy: STORMGLASS_API_KEY must be set")
+	}
+
+	var cache stormglass.Cache = stormglass.NewMemoryCache(*cacheSize)
+	if *cacheDir != "" {
+		fileCache, err := stormglass.NewFileCache(*cacheDir)
+		if err != nil {
+			log.Fatalf("stormglass-proxy: %v", err)
+		}
+
+		cache = fileCache
+	}
+
+	opts := []stormglass.Option{
+		stormglass.WithBaseURL(*baseURL),
+		stormglass.WithUserAgent("stormglass-proxy"),
+		stormglass.WithRetryPolicy(stormglass.DefaultRetryPolicy()),
+		stormglass.WithRateLimiter(stormglass.NewRateLimiter(*rate, *dailyMax)),
+		stormglass.WithCache(cache),
+	}
+
+	for _, endpoint := range endpoints() {
+		opts = append(opts, stormglass.WithCacheTTL(endpoint, *cacheTTL))
+	}
+
+	client := stormglass.NewClient(apiKey, opts...)
+	if err := client.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           NewProxy(client),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_ = server.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "stormglass-proxy: listening on %s\n", *addr)
+
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("stormglass-proxy: %v", err)
+	}
+}
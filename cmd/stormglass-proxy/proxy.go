@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+const basePath = "/v2/"
+
+// endpoints returns the endpoints forwarded by the proxy.
+func endpoints() []stormglass.Endpoint {
+	return []stormglass.Endpoint{
+		stormglass.EndpointWeatherPoint,
+		stormglass.EndpointBioPoint,
+		stormglass.EndpointTideExtremesPoint,
+		stormglass.EndpointTideSeaLevelPoint,
+		stormglass.EndpointTideStations,
+		stormglass.EndpointTideStationsArea,
+		stormglass.EndpointAstronomyPoint,
+		stormglass.EndpointSolarPoint,
+		stormglass.EndpointElevationPoint,
+	}
+}
+
+// Proxy is an http.Handler forwarding Stormglass API requests through a Client.
+type Proxy struct {
+	client *stormglass.Client
+	mux    *http.ServeMux
+}
+
+// NewProxy returns a Proxy forwarding requests through the client, whose
+// cache, rate limiter and usage ledger are shared by all callers. It appends a
+// hook to the client Hooks to report cache hits in the X-Cache header.
+func NewProxy(client *stormglass.Client) *Proxy {
+	p := &Proxy{client: client, mux: http.NewServeMux()}
+
+	client.Hooks = append(client.Hooks, stormglass.HookFuncs{After: recordCacheStatus})
+
+	for _, endpoint := range endpoints() {
+		p.mux.Handle(basePath+string(endpoint), p.forward(endpoint))
+	}
+
+	p.mux.HandleFunc("/usage", p.usage)
+	p.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "path", "Not found")
+	})
+
+	return p
+}
+
+// ServeHTTP serves a request.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mux.ServeHTTP(w, r)
+}
+
+func (p *Proxy) forward(endpoint stormglass.Endpoint) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method", "Method not allowed")
+			return
+		}
+
+		// callers never hold the key, the client injects it.
+		values := r.URL.Query()
+		values.Del("key")
+
+		status := &cacheStatus{}
+		ctx := context.WithValue(r.Context(), cacheStatusKey{}, status)
+
+		res, err := p.client.GetRaw(ctx, endpoint, values)
+
+		var apiErr *stormglass.Error
+
+		switch {
+		case errors.As(err, &apiErr):
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(apiErr.StatusCode)
+			_, _ = w.Write(apiErr.Body)
+		case errors.Is(err, stormglass.ErrDailyLimitReached):
+			writeError(w, http.StatusTooManyRequests, "proxy", "Daily request limit of the proxy reached")
+		case errors.Is(err, context.Canceled):
+			// the caller is gone, nothing to answer.
+		case err != nil:
+			writeError(w, http.StatusBadGateway, "proxy", err.Error())
+		default:
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Header().Set("X-Cache", status.String())
+			_, _ = w.Write(res.Body)
+		}
+	}
+}
+
+func (p *Proxy) usage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method", "Method not allowed")
+		return
+	}
+
+	usage := p.client.Usage()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(struct {
+		stormglass.Usage
+		Remaining int `json:"remaining"`
+	}{usage, usage.Remaining()})
+}
+
+// writeError writes an error formatted like the API errors.
+func writeError(w http.ResponseWriter, status int, key, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": map[string]string{key: message},
+	})
+}
+
+type cacheStatusKey struct{}
+
+// cacheStatus records whether the response of a request was cached.
+type cacheStatus struct {
+	cached bool
+}
+
+func (s *cacheStatus) String() string {
+	if s.cached {
+		return "HIT"
+	}
+
+	return "MISS"
+}
+
+func recordCacheStatus(ctx context.Context, info stormglass.ResponseInfo) {
+	if status, ok := ctx.Value(cacheStatusKey{}).(*cacheStatus); ok {
+		status.cached = info.Cached
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stormglass "github.com/yawlhead91/stormglassgo"
+	"github.com/yawlhead91/stormglassgo/stormglasstest"
+)
+
+func TestProxy(t *testing.T) {
+	const query = "?lat=58.7984&lng=17.8081&params=waveHeight&start=1685577600&end=1685581200"
+
+	get := func(t *testing.T, url string, header http.Header) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+		require.NoError(t, err)
+
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		return res, string(body)
+	}
+
+	t.Run("forwards requests with the key and caches responses", func(t *testing.T) {
+		upstream := stormglasstest.NewServer()
+		defer upstream.Close()
+
+		proxy := httptest.NewServer(NewProxy(upstream.NewClient(stormglass.WithCache(stormglass.NewMemoryCache(10)))))
+		defer proxy.Close()
+
+		res, body := get(t, proxy.URL+"/v2/weather/point"+query, http.Header{"Authorization": {"a-service-key"}})
+		require.Equal(t, http.StatusOK, res.StatusCode, body)
+		assert.Equal(t, "MISS", res.Header.Get("X-Cache"))
+
+		var points stormglass.Points
+		require.NoError(t, json.Unmarshal([]byte(body), &points))
+		assert.Len(t, points.Hours, 2)
+
+		res, cached := get(t, proxy.URL+"/v2/weather/point"+query+"&key=another-key", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "HIT", res.Header.Get("X-Cache"))
+		assert.JSONEq(t, body, cached)
+		assert.Equal(t, 1, upstream.RequestCount(), "expected the second request to be served from cache")
+
+		res, body = get(t, proxy.URL+"/usage", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var usage struct {
+			stormglass.Usage
+			Remaining int `json:"remaining"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &usage))
+		assert.Equal(t, 1, usage.Requests)
+		assert.Equal(t, stormglasstest.DefaultDailyQuota-1, usage.Remaining)
+	})
+
+	t.Run("forwards api errors", func(t *testing.T) {
+		upstream := stormglasstest.NewServer()
+		defer upstream.Close()
+
+		proxy := httptest.NewServer(NewProxy(upstream.NewClient()))
+		defer proxy.Close()
+
+		res, body := get(t, proxy.URL+"/v2/weather/point?lat=100", nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+		assert.Contains(t, body, `"lat"`)
+
+		upstream.FailNext(http.StatusTooManyRequests, 1)
+		res, _ = get(t, proxy.URL+"/v2/elevation/point?lat=1&lng=1", nil)
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	})
+
+	t.Run("enforces the proxy daily limit", func(t *testing.T) {
+		upstream := stormglasstest.NewServer()
+		defer upstream.Close()
+
+		client := upstream.NewClient(stormglass.WithRateLimiter(stormglass.NewRateLimiter(100, 1)))
+		proxy := httptest.NewServer(NewProxy(client))
+		defer proxy.Close()
+
+		res, _ := get(t, proxy.URL+"/v2/elevation/point?lat=1&lng=1", nil)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		res, body := get(t, proxy.URL+"/v2/elevation/point?lat=1&lng=1", nil)
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.Contains(t, body, "Daily request limit")
+	})
+
+	t.Run("rejects unknown paths and methods", func(t *testing.T) {
+		upstream := stormglasstest.NewServer()
+		defer upstream.Close()
+
+		proxy := httptest.NewServer(NewProxy(upstream.NewClient()))
+		defer proxy.Close()
+
+		res, _ := get(t, proxy.URL+"/v2/forecast", nil)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		res, err := http.Post(proxy.URL+"/v2/weather/point", "application/json", http.NoBody)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Zero(t, upstream.RequestCount())
+	})
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+// flags selects the flags of a command.
+type flags int
+
+const (
+	flagPoint flags = 1 << iota
+	flagRange
+	flagParams
+	flagSources
+	flagDatum
+	flagBox
+)
+
+type command struct {
+	name    string
+	summary string
+	flags   flags
+	// run returns the response, printed as is in JSON, and its table.
+	run func(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error)
+}
+
+// commands returns the commands in the order they are listed.
+func commands() []command {
+	return []command{
+		{"weather", "hourly weather of a point", flagPoint | flagRange | flagParams | flagSources, weather},
+		{"bio", "hourly biological ocean data of a point", flagPoint | flagRange | flagParams | flagSources, bio},
+		{"solar", "hourly solar radiation of a point", flagPoint | flagRange | flagParams | flagSources, solar},
+		{"extremes", "high and low tides of a point", flagPoint | flagRange | flagDatum, extremes},
+		{"sea-level", "hourly sea level of a point", flagPoint | flagRange | flagDatum, seaLevel},
+		{"stations", "tide stations, optionally in a box", flagBox, stations},
+		{"astronomy", "sun and moon times of a point", flagPoint | flagRange, astronomy},
+		{"elevation", "elevation or depth of a point", flagPoint, elevation},
+	}
+}
+
+// options holds the flags of all commands.
+type options struct {
+	lat     float64
+	lng     float64
+	start   string
+	end     string
+	params  string
+	sources string
+	datum   string
+	box     string
+	format  string
+	config  string
+	baseURL string
+}
+
+func (o *options) register(fs *flag.FlagSet, f flags) {
+	if f&flagPoint != 0 {
+		fs.Float64Var(&o.lat, "lat", 0, "latitude of the point")
+		fs.Float64Var(&o.lng, "lng", 0, "longitude of the point")
+	}
+
+	if f&flagRange != 0 {
+		fs.StringVar(&o.start, "start", "", "start time in UTC, formatted as 2006-01-02 or RFC 3339")
+		fs.StringVar(&o.end, "end", "", "end time in UTC, formatted as 2006-01-02 or RFC 3339")
+	}
+
+	if f&flagParams != 0 {
+		fs.StringVar(&o.params, "params", "", "comma separated params, e.g. waveHeight,windSpeed")
+	}
+
+	if f&flagSources != 0 {
+		fs.StringVar(&o.sources, "sources", "", "comma separated sources, e.g. sg,noaa")
+	}
+
+	if f&flagDatum != 0 {
+		fs.StringVar(&o.datum, "datum", "", "tide datum, MSL or MLLW")
+	}
+
+	if f&flagBox != 0 {
+		fs.StringVar(&o.box, "box", "", "bounding box as topRightLat,topRightLng:bottomLeftLat,bottomLeftLng")
+	}
+
+	fs.StringVar(&o.format, "format", "table", "output format: table, json or csv")
+	fs.StringVar(&o.config, "config", "", "config file, by default stormglass/config.json in the user config directory")
+	fs.StringVar(&o.baseURL, "base-url", "", "base URL of the API")
+}
+
+func (o *options) common() (stormglass.CommonRequestOptions, error) {
+	common := stormglass.CommonRequestOptions{Lat: o.lat, Lng: o.lng}
+
+	if o.start != "" {
+		start, err := parseTime("start", o.start)
+		if err != nil {
+			return common, err
+		}
+
+		common.Start = &start
+	}
+
+	if o.end != "" {
+		end, err := parseTime("end", o.end)
+		if err != nil {
+			return common, err
+		}
+
+		common.End = &end
+	}
+
+	return common, nil
+}
+
+func (o *options) tideDatum() (stormglass.ExtremesPointsDatumOption, error) {
+	switch datum := stormglass.ExtremesPointsDatumOption(strings.ToUpper(o.datum)); datum {
+	case "", stormglass.MSL, stormglass.MLLW:
+		return datum, nil
+	default:
+		return "", fmt.Errorf("invalid datum %q: must be MSL or MLLW", o.datum)
+	}
+}
+
+// parseTime parses a time flag formatted as a date, a date and time or RFC 3339.
+func parseTime(name, value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s %q: must be formatted as 2006-01-02 or RFC 3339", name, value)
+}
+
+// setParams enables the boolean fields of a params options struct named like
+// the params, ignoring case.
+func setParams(params interface{}, names string) error {
+	if names == "" {
+		return nil
+	}
+
+	v := reflect.ValueOf(params).Elem()
+	for _, name := range strings.Split(names, ",") {
+		field := v.FieldByNameFunc(func(field string) bool {
+			return strings.EqualFold(field, strings.TrimSpace(name))
+		})
+
+		if !field.IsValid() || field.Kind() != reflect.Bool {
+			return fmt.Errorf("unknown param %q", name)
+		}
+
+		field.SetBool(true)
+	}
+
+	return nil
+}
+
+// setSources enables the sources of the comma separated names.
+func setSources(names string, sources map[string]*bool) error {
+	if names == "" {
+		return nil
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		source, ok := sources[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return fmt.Errorf("unknown source %q", name)
+		}
+
+		*source = true
+	}
+
+	return nil
+}
+
+func weather(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		req stormglass.PointsRequestOptions
+		err error
+	)
+
+	if req.CommonRequestOptions, err = o.common(); err != nil {
+		return nil, table{}, err
+	}
+
+	if err = setParams(&req.Params, o.params); err != nil {
+		return nil, table{}, err
+	}
+
+	s := &req.Source
+	err = setSources(o.sources, map[string]*bool{
+		"icon": &s.ICON, "dwd": &s.DWD, "noaa": &s.NOAA, "meteo": &s.MeteoFrance, "meto": &s.UKMetOffice,
+		"fcoo": &s.FCOO, "fmi": &s.FMI, "yr": &s.YR, "smhi": &s.SMHI, "sg": &s.StormGlass,
+	})
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.GetPoint(ctx, req)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t, err := hourlyTable(res.Hours)
+
+	return res, t, err
+}
+
+func bio(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		req stormglass.BioPointRequestOptions
+		err error
+	)
+
+	if req.CommonRequestOptions, err = o.common(); err != nil {
+		return nil, table{}, err
+	}
+
+	if err = setParams(&req.Params, o.params); err != nil {
+		return nil, table{}, err
+	}
+
+	s := &req.Source
+	if err = setSources(o.sources, map[string]*bool{"meteo": &s.MeteoFrance, "sg": &s.StormGlass}); err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.GetBioPoint(ctx, req)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t, err := hourlyTable(res.Hours)
+
+	return res, t, err
+}
+
+func solar(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		req stormglass.SolarPointRequestOptions
+		err error
+	)
+
+	if req.CommonRequestOptions, err = o.common(); err != nil {
+		return nil, table{}, err
+	}
+
+	if err = setParams(&req.Params, o.params); err != nil {
+		return nil, table{}, err
+	}
+
+	if err = setSources(o.sources, map[string]*bool{"sg": &req.Source.StormGlass}); err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.GetSolarPoint(ctx, req)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t, err := hourlyTable(res.Hours)
+
+	return res, t, err
+}
+
+func extremes(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		req stormglass.ExtremesPointsRequestOptions
+		err error
+	)
+
+	if req.CommonRequestOptions, err = o.common(); err != nil {
+		return nil, table{}, err
+	}
+
+	if req.Datum, err = o.tideDatum(); err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.GetExtremesPoint(ctx, req)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"time", "type", "height"}}
+	for _, e := range res.Data {
+		t.rows = append(t.rows, []string{formatTime(&e.Time), e.Type, formatFloat(e.Height)})
+	}
+
+	return res, t, nil
+}
+
+func seaLevel(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		req stormglass.SeaLevelPointRequestOptions
+		err error
+	)
+
+	if req.CommonRequestOptions, err = o.common(); err != nil {
+		return nil, table{}, err
+	}
+
+	if req.Datum, err = o.tideDatum(); err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.GetSeaLevelPoint(ctx, req)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"time", "seaLevel"}}
+	for _, p := range res.Data {
+		t.rows = append(t.rows, []string{formatTime(&p.Time), formatFloat(p.SeaLevel)})
+	}
+
+	return res, t, nil
+}
+
+func stations(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		res *stormglass.TideStations
+		err error
+	)
+
+	if o.box == "" {
+		res, err = c.ListTideStations(ctx)
+	} else {
+		var box stormglass.TideStationsAreaRequestOptions
+		if box, err = parseBox(o.box); err != nil {
+			return nil, table{}, err
+		}
+
+		res, err = c.ListTideStationsInArea(ctx, box)
+	}
+
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{"name", "lat", "lng", "source"}}
+	for _, s := range res.Data {
+		t.rows = append(t.rows, []string{s.Name, formatFloat(s.Lat), formatFloat(s.Lng), s.Source})
+	}
+
+	return res, t, nil
+}
+
+func parseBox(box string) (stormglass.TideStationsAreaRequestOptions, error) {
+	var coords []float64
+	for _, corner := range strings.Split(box, ":") {
+		for _, coord := range strings.Split(corner, ",") {
+			v, err := strconv.ParseFloat(strings.TrimSpace(coord), 64)
+			if err != nil {
+				return stormglass.TideStationsAreaRequestOptions{}, fmt.Errorf("invalid box %q: %w", box, err)
+			}
+
+			coords = append(coords, v)
+		}
+	}
+
+	if len(coords) != 4 || !strings.Contains(box, ":") {
+		return stormglass.TideStationsAreaRequestOptions{}, fmt.Errorf(
+			"invalid box %q: must be formatted as topRightLat,topRightLng:bottomLeftLat,bottomLeftLng", box)
+	}
+
+	return stormglass.TideStationsAreaRequestOptions{
+		TopRightLat:   coords[0],
+		TopRightLng:   coords[1],
+		BottomLeftLat: coords[2],
+		BottomLeftLng: coords[3],
+	}, nil
+}
+
+func astronomy(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	var (
+		req stormglass.AstronomyPointRequestOptions
+		err error
+	)
+
+	if req.CommonRequestOptions, err = o.common(); err != nil {
+		return nil, table{}, err
+	}
+
+	res, err := c.GetAstronomyPoint(ctx, req)
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{header: []string{
+		"date", "sunrise", "sunset", "civilDawn", "civilDusk", "moonrise", "moonset", "moonPhase", "moonFraction",
+	}}
+	for _, d := range res.Data {
+		t.rows = append(t.rows, []string{
+			d.Time.UTC().Format("2006-01-02"),
+			formatTime(d.Sunrise),
+			formatTime(d.Sunset),
+			formatTime(d.CivilDawn),
+			formatTime(d.CivilDusk),
+			formatTime(d.Moonrise),
+			formatTime(d.Moonset),
+			d.MoonPhase.Current.Text,
+			formatFloat(d.MoonFraction),
+		})
+	}
+
+	return res, t, nil
+}
+
+func elevation(ctx context.Context, c *stormglass.Client, o *options) (interface{}, table, error) {
+	res, err := c.GetElevationPoint(ctx, stormglass.ElevationPointRequestOptions{Lat: o.lat, Lng: o.lng})
+	if err != nil {
+		return nil, table{}, err
+	}
+
+	t := table{
+		header: []string{"lat", "lng", "elevation", "unit"},
+		rows:   [][]string{{formatFloat(o.lat), formatFloat(o.lng), formatFloat(res.Data.Elevation), res.Meta.Unit}},
+	}
+
+	return res, t, nil
+}
@@ -0,0 +1,205 @@
+// Command stormglass queries the Stormglass API from the command line.
+//
+// Usage:
+//
+//	stormglass <command> [flags]
+//
+// The commands are weather, bio, solar, extremes, sea-level, stations,
+// astronomy and elevation. Run stormglass <command> -h for their flags.
+//
+// The API key is read from the STORMGLASS_API_KEY environment variable, or
+// from the apiKey field of a JSON config file, by default stormglass/config.json
+// in the user config directory:
+//
+//	{"apiKey": "...", "baseURL": "https://api.stormglass.io/v2"}
+//
+// Results are printed as a table, JSON or CSV.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+// errUsage is returned for invalid command lines, after printing the usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr)
+	switch {
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "stormglass:", err)
+		os.Exit(1)
+	}
+}
+
+// config is the config file of the command.
+type config struct {
+	APIKey  string `json:"apiKey"`
+	BaseURL string `json:"baseURL"`
+}
+
+// run runs the command line args, reading the environment with getenv.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		printUsage(stderr)
+		return errUsage
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		printUsage(stderr)
+
+		return errUsage
+	}
+
+	o := &options{}
+	fs := flag.NewFlagSet("stormglass "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	o.register(fs, cmd.flags)
+
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return errUsage
+	}
+
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments %q\n", fs.Args())
+		fs.Usage()
+
+		return errUsage
+	}
+
+	format, err := parseFormat(o.format)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient(o, getenv)
+	if err != nil {
+		return err
+	}
+
+	res, t, err := cmd.run(ctx, c, o)
+	if err != nil {
+		return err
+	}
+
+	return write(stdout, format, res, t)
+}
+
+// newClient returns a client configured from the flags, the environment and
+// the config file, in that order of precedence.
+func newClient(o *options, getenv func(string) string) (*stormglass.Client, error) {
+	cfg, err := loadConfig(o.config, getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := getenv("STORMGLASS_API_KEY")
+	if apiKey == "" {
+		apiKey = cfg.APIKey
+	}
+
+	if apiKey == "" {
+		return nil, errors.New("missing API key: set STORMGLASS_API_KEY or apiKey in the config file")
+	}
+
+	baseURL := o.baseURL
+	if baseURL == "" {
+		baseURL = cfg.BaseURL
+	}
+
+	opts := []stormglass.Option{
+		stormglass.WithUserAgent("stormglass-cli"),
+		stormglass.WithRetryPolicy(stormglass.DefaultRetryPolicy()),
+	}
+
+	if baseURL != "" {
+		opts = append(opts, stormglass.WithBaseURL(baseURL))
+	}
+
+	c := stormglass.NewClient(apiKey, opts...)
+
+	return c, c.Err()
+}
+
+// loadConfig reads the config file at path, or at the default path when
+// empty. A missing default config file is not an error.
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	var cfg config
+
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigPath(getenv)
+		if path == "" {
+			return cfg, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return cfg, fmt.Errorf("config: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func defaultConfigPath(getenv func(string) string) string {
+	dir := getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		var err error
+		if dir, err = os.UserConfigDir(); err != nil {
+			return ""
+		}
+	}
+
+	return filepath.Join(dir, "stormglass", "config.json")
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprint(w, "Usage: stormglass <command> [flags]\n\nCommands:\n")
+
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+
+	fmt.Fprint(w, "\nRun stormglass <command> -h for the flags of a command.\n")
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name || strings.EqualFold(strings.ReplaceAll(cmd.name, "-", ""), name) {
+			return cmd, true
+		}
+	}
+
+	return command{}, false
+}
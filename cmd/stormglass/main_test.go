@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stormglass "github.com/yawlhead91/stormglassgo"
+	"github.com/yawlhead91/stormglassgo/stormglasstest"
+)
+
+func TestRun(t *testing.T) {
+	s := stormglasstest.NewServer()
+	defer s.Close()
+
+	env := map[string]string{
+		"STORMGLASS_API_KEY": s.APIKey(),
+		"XDG_CONFIG_HOME":    t.TempDir(),
+	}
+
+	getenv := func(key string) string { return env[key] }
+
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		args = append(args, "-base-url", s.BaseURL())
+		err := run(context.Background(), args, getenv, &stdout, &stderr)
+
+		return stdout.String(), stderr.String(), err
+	}
+
+	t.Run("prints weather as table", func(t *testing.T) {
+		stdout, _, err := run("weather",
+			"-lat", "58.7984", "-lng", "17.8081",
+			"-start", "2023-06-01", "-end", "2023-06-01T03:00",
+			"-params", "waveHeight,windSpeed", "-sources", "sg,noaa",
+		)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 5)
+		header := []string{"time", "waveHeight.noaa", "waveHeight.sg", "windSpeed.noaa", "windSpeed.sg"}
+		assert.Equal(t, header, strings.Fields(lines[0]))
+		assert.True(t, strings.HasPrefix(lines[1], "2023-06-01T00:00:00Z"))
+	})
+
+	t.Run("sends the dwd source", func(t *testing.T) {
+		stdout, _, err := run("weather",
+			"-lat", "58.7984", "-lng", "17.8081",
+			"-start", "2023-06-01", "-end", "2023-06-01T01:00",
+			"-params", "windSpeed", "-sources", "dwd",
+		)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		assert.Equal(t, []string{"time", "windSpeed.dwd"}, strings.Fields(lines[0]))
+	})
+
+	t.Run("prints tides as csv", func(t *testing.T) {
+		stdout, _, err := run("extremes",
+			"-lat", "58.7984", "-lng", "17.8081",
+			"-start", "2023-06-01", "-end", "2023-06-02",
+			"-datum", "mllw", "-format", "csv",
+		)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+		require.NoError(t, err)
+		require.Greater(t, len(records), 3)
+		assert.Equal(t, []string{"time", "type", "height"}, records[0])
+	})
+
+	t.Run("prints json", func(t *testing.T) {
+		stdout, _, err := run("astronomy", "-lat", "58.7984", "-lng", "17.8081", "-start", "2023-06-01", "-format", "json")
+		require.NoError(t, err)
+
+		var res stormglass.AstronomyPoints
+		require.NoError(t, json.Unmarshal([]byte(stdout), &res))
+		assert.Len(t, res.Data, 1)
+	})
+
+	t.Run("runs the other commands", func(t *testing.T) {
+		commands := [][]string{
+			{"bio", "-lat", "1", "-lng", "1", "-params", "ph"},
+			{"solar", "-lat", "1", "-lng", "1", "-params", "uvIndex", "-sources", "sg"},
+			{"sea-level", "-lat", "1", "-lng", "1"},
+			{"stations"},
+			{"stations", "-box", "90,180:0,0"},
+			{"elevation", "-lat", "1", "-lng", "1"},
+		}
+
+		for _, args := range commands {
+			stdout, _, err := run(args...)
+			require.NoError(t, err, args)
+			assert.Greater(t, len(strings.Split(stdout, "\n")), 2, args)
+		}
+	})
+
+	t.Run("reports invalid flags", func(t *testing.T) {
+		invalid := [][]string{
+			{"weather", "-params", "foo"},
+			{"weather", "-params", "waveHeight", "-sources", "foo"},
+			{"weather", "-params", "waveHeight", "-start", "yesterday"},
+			{"extremes", "-datum", "foo"},
+			{"stations", "-box", "1,2"},
+			{"elevation", "-format", "xml"},
+		}
+
+		for _, args := range invalid {
+			_, _, err := run(args...)
+			assert.Error(t, err, args)
+		}
+
+		_, stderr, err := run("forecast")
+		assert.ErrorIs(t, err, errUsage)
+		assert.Contains(t, stderr, "unknown command")
+
+		_, _, err = run("weather", "-lat", "foo")
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("reports api errors", func(t *testing.T) {
+		_, _, err := run("weather", "-lat", "1", "-lng", "1")
+		assert.ErrorIs(t, err, stormglass.ErrInvalidParameters)
+	})
+
+	t.Run("reads the key from the config file", func(t *testing.T) {
+		env := map[string]string{"XDG_CONFIG_HOME": t.TempDir()}
+		getenv := func(key string) string { return env[key] }
+
+		var stdout bytes.Buffer
+		err := runWithEnv(getenv, &stdout, "elevation", "-lat", "1", "-lng", "1")
+		assert.ErrorContains(t, err, "missing API key")
+
+		dir := filepath.Join(env["XDG_CONFIG_HOME"], "stormglass")
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		cfg, err := json.Marshal(config{APIKey: s.APIKey(), BaseURL: s.BaseURL()})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), cfg, 0o600))
+
+		err = runWithEnv(getenv, &stdout, "elevation", "-lat", "1", "-lng", "1")
+		assert.NoError(t, err)
+
+		err = runWithEnv(getenv, &stdout, "elevation", "-config", filepath.Join(dir, "missing.json"))
+		assert.Error(t, err, "expected an explicit config file to exist")
+	})
+}
+
+func runWithEnv(getenv func(string) string, stdout *bytes.Buffer, args ...string) error {
+	return run(context.Background(), args, getenv, stdout, &bytes.Buffer{})
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type format string
+
+const (
+	formatTable format = "table"
+	formatJSON  format = "json"
+	formatCSV   format = "csv"
+)
+
+func parseFormat(s string) (format, error) {
+	switch f := format(strings.ToLower(s)); f {
+	case formatTable, formatJSON, formatCSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid format %q: must be table, json or csv", s)
+	}
+}
+
+// table is the tabular form of a response.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// write writes the response in the format, using its table for table and CSV.
+func write(w io.Writer, f format, res interface{}, t table) error {
+	switch f {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(res)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		_ = cw.Write(t.header)
+		_ = cw.WriteAll(t.rows)
+
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+
+		return tw.Flush()
+	}
+}
+
+// hourlyTable returns a table of hourly data points, with a column per param
+// and source named param.source, e.g. waveHeight.sg.
+func hourlyTable(hours interface{}) (table, error) {
+	data, err := json.Marshal(hours)
+	if err != nil {
+		return table{}, err
+	}
+
+	var points []map[string]json.RawMessage
+	if err = json.Unmarshal(data, &points); err != nil {
+		return table{}, err
+	}
+
+	values := make([]map[string]float64, len(points))
+	columns := map[string]bool{}
+
+	for i, point := range points {
+		values[i] = map[string]float64{}
+
+		for param, raw := range point {
+			var sources map[string]float64
+			if param == "time" || json.Unmarshal(raw, &sources) != nil {
+				continue
+			}
+
+			for source, v := range sources {
+				column := param + "." + source
+				columns[column] = true
+				values[i][column] = v
+			}
+		}
+	}
+
+	t := table{header: []string{"time"}}
+	for column := range columns {
+		t.header = append(t.header, column)
+	}
+
+	sort.Strings(t.header[1:])
+
+	for i, point := range points {
+		var ts string
+		_ = json.Unmarshal(point["time"], &ts)
+
+		row := []string{ts}
+		for _, column := range t.header[1:] {
+			if v, ok := values[i][column]; ok {
+				row = append(row, formatFloat(v))
+			} else {
+				row = append(row, "")
+			}
+		}
+
+		t.rows = append(t.rows, row)
+	}
+
+	return t, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -1,6 +1,12 @@
 package stormglass
 
-import "time"
+import (
+	"encoding/json"
+	"sort"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
 
 // CommonRequestOptions contains common request options.
 type CommonRequestOptions struct {
@@ -21,3 +27,37 @@ type Meta struct {
 	RequestCount int      `json:"requestCount,omitempty"`
 	Start        string   `json:"start,omitempty"`
 }
+
+// paramsToList returns the lower camel case names of the enabled boolean
+// fields of a params options struct, sorted alphabetically.
+func paramsToList(p interface{}) []string {
+	var params []string
+
+	b, _ := json.Marshal(p)
+	var m map[string]bool
+	_ = json.Unmarshal(b, &m)
+
+	for k, v := range m {
+		if v {
+			params = append(params, firstToLower(k))
+		}
+	}
+
+	sort.Slice(params, func(i, j int) bool {
+		return params[i] < params[j]
+	})
+
+	return params
+}
+
+func firstToLower(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size <= 1 {
+		return s
+	}
+	lc := unicode.ToLower(r)
+	if r == lc {
+		return s
+	}
+	return string(lc) + s[size:]
+}
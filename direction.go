@@ -0,0 +1,207 @@
+package stormglass
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// compassPoints returns the names of the 16 compass points clockwise from north.
+func compassPoints() [16]string {
+	return [16]string{
+		"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+		"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+	}
+}
+
+// Compass returns the name of the closest of the 16 compass points to the
+// direction in degrees, e.g. "SSW" for 200, empty when it is NaN or infinite.
+func Compass(degrees float64) string {
+	if math.IsNaN(degrees) || math.IsInf(degrees, 0) {
+		return ""
+	}
+
+	points := compassPoints()
+
+	return points[int(math.Round(normalizeDegrees(degrees)/22.5))%len(points)]
+}
+
+// AngularDifference returns the smallest angle between two directions in
+// degrees, from 0 to 180.
+func AngularDifference(a, b float64) float64 {
+	return math.Abs(signedDifference(a, b))
+}
+
+// CircularMean returns the mean of directions in degrees, from 0 to 360. It is
+// NaN when empty or when the directions cancel out, e.g. 90 and 270.
+func CircularMean(degrees []float64) float64 {
+	mean, _ := circularMean(degrees)
+
+	return mean
+}
+
+// CircularStdDev returns the circular standard deviation of directions in
+// degrees. Like CircularMean, it is NaN when empty or when the directions
+// cancel out, e.g. 90 and 270.
+func CircularStdDev(degrees []float64) float64 {
+	_, length := circularMean(degrees)
+	if length <= 0 {
+		return math.NaN()
+	}
+
+	return math.Sqrt(-2*math.Log(math.Min(length, 1))) * 180 / math.Pi
+}
+
+// Vector holds the eastward U and northward V components of a flow, e.g. the
+// wind. Directions follow the meteorological convention of the API: they are
+// where the flow comes from, so a wind from the north has a negative V.
+type Vector struct {
+	U float64 `json:"u"`
+	V float64 `json:"v"`
+}
+
+// NewVector returns the components of a flow of speed coming from the
+// direction in degrees.
+func NewVector(speed, direction float64) Vector {
+	rad := direction * math.Pi / 180
+
+	return Vector{U: -speed * math.Sin(rad), V: -speed * math.Cos(rad)}
+}
+
+// Speed returns the speed of the flow.
+func (v Vector) Speed() float64 {
+	return math.Hypot(v.U, v.V)
+}
+
+// Direction returns the direction the flow comes from in degrees, from 0 to 360.
+// A calm flow, whose components are both 0, has no direction: it is NaN.
+func (v Vector) Direction() float64 {
+	if v.U == 0 && v.V == 0 {
+		return math.NaN()
+	}
+
+	return normalizeDegrees(math.Atan2(-v.U, -v.V) * 180 / math.Pi)
+}
+
+// Vector returns the flow of the source for the speed and direction variables
+// named like their params, e.g. "windSpeed" and "windDirection", false when
+// either is missing.
+func (h *Hour) Vector(speed, direction string, source Source) (Vector, bool) {
+	s, ok := h.Variable(speed).Get(source)
+	if !ok {
+		return Vector{}, false
+	}
+
+	d, ok := h.Variable(direction).Get(source)
+	if !ok {
+		return Vector{}, false
+	}
+
+	return NewVector(s, d), true
+}
+
+// DirectionStats returns circular statistics of the values of the present
+// sources, false when none is present. Values are in degrees; see
+// newDirectionStats for how the statistics wrap around north.
+func (v *WeatherSourceValues) DirectionStats() (SourceStats, bool) {
+	values := v.Values()
+	if len(values) == 0 {
+		return SourceStats{}, false
+	}
+
+	floats := make([]float64, len(values))
+	for i, value := range values {
+		floats[i] = value.Value
+	}
+
+	stats, _ := newDirectionStats(floats)
+
+	return stats, true
+}
+
+// isDirection reports whether the variable named like its param is a direction.
+func isDirection(name string) bool {
+	return strings.Contains(name, "Direction")
+}
+
+// newDirectionStats returns the statistics of directions, which must not be
+// empty, along with the directions unwrapped around their circular mean so
+// that linear statistics apply. Min and Max bound the arc holding the
+// directions clockwise, so Min exceeds Max when the arc crosses north. Spread
+// is the width of the arc.
+func newDirectionStats(degrees []float64) (SourceStats, []float64) {
+	mean, _ := circularMean(degrees)
+
+	reference := mean
+	if math.IsNaN(reference) {
+		reference = degrees[0]
+	}
+
+	unwrapped := make([]float64, len(degrees))
+	for i, d := range degrees {
+		unwrapped[i] = reference + signedDifference(d, reference)
+	}
+
+	sort.Float64s(unwrapped)
+
+	stats := newSourceStats(unwrapped)
+	if math.IsNaN(mean) {
+		mean = stats.Mean
+	}
+
+	stats.Mean = normalizeDegrees(mean)
+	stats.Median = normalizeDegrees(stats.Median)
+	stats.Min = normalizeDegrees(stats.Min)
+	stats.Max = normalizeDegrees(stats.Max)
+
+	return stats, unwrapped
+}
+
+// circularMean returns the mean direction of degrees and the length of the
+// mean resultant vector, from 0 when the directions cancel out to 1 when they
+// are all equal. The mean is NaN when the length is close to 0.
+func circularMean(degrees []float64) (float64, float64) {
+	if len(degrees) == 0 {
+		return math.NaN(), 0
+	}
+
+	var sin, cos float64
+	for _, d := range degrees {
+		rad := d * math.Pi / 180
+		sin += math.Sin(rad)
+		cos += math.Cos(rad)
+	}
+
+	n := float64(len(degrees))
+	length := math.Hypot(sin, cos) / n
+
+	if length < 1e-9 {
+		return math.NaN(), 0
+	}
+
+	return normalizeDegrees(math.Atan2(sin, cos) * 180 / math.Pi), length
+}
+
+// signedDifference returns the angle from b to a in degrees, from -180 to 180.
+func signedDifference(a, b float64) float64 {
+	d := math.Mod(a-b, 360)
+
+	switch {
+	case d > 180:
+		d -= 360
+	case d <= -180:
+		d += 360
+	}
+
+	return d
+}
+
+// normalizeDegrees returns the direction in degrees from 0 to 360.
+func normalizeDegrees(degrees float64) float64 {
+	d := math.Mod(degrees, 360)
+	if d < 0 {
+		d += 360
+	}
+
+	return d
+}
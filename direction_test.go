@@ -0,0 +1,119 @@
+package stormglass
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompass(t *testing.T) {
+	tests := map[float64]string{
+		0:      "N",
+		11.24:  "N",
+		11.25:  "NNE",
+		45:     "NE",
+		200:    "SSW",
+		270:    "W",
+		348.74: "NNW",
+		350:    "N",
+		360:    "N",
+		-90:    "W",
+		720.5:  "N",
+	}
+
+	for degrees, want := range tests {
+		assert.Equal(t, want, Compass(degrees), "%v°", degrees)
+	}
+
+	for _, degrees := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		assert.Empty(t, Compass(degrees), "%v°", degrees)
+	}
+}
+
+func TestCircularStatistics(t *testing.T) {
+	t.Run("averages across north", func(t *testing.T) {
+		assert.InDelta(t, 0, AngularDifference(CircularMean([]float64{350, 10}), 0), 1e-9)
+		assert.InDelta(t, 359, CircularMean([]float64{340, 350, 10, 20, 355}), 0.1)
+		assert.InDelta(t, 90, CircularMean([]float64{90}), 1e-9)
+		assert.True(t, math.IsNaN(CircularMean([]float64{90, 270})), "expected NaN for opposite directions")
+		assert.True(t, math.IsNaN(CircularMean(nil)), "expected NaN without values")
+	})
+
+	t.Run("computes the standard deviation", func(t *testing.T) {
+		assert.InDelta(t, 0, CircularStdDev([]float64{120, 120}), 1e-6)
+		assert.InDelta(t, CircularStdDev([]float64{80, 100}), CircularStdDev([]float64{350, 10}), 1e-9)
+		assert.InDelta(t, 10, CircularStdDev([]float64{350, 10}), 0.2)
+		assert.True(t, math.IsNaN(CircularStdDev([]float64{0, 180})), "expected NaN for opposite directions")
+		assert.True(t, math.IsNaN(CircularStdDev(nil)))
+	})
+
+	t.Run("measures angular differences", func(t *testing.T) {
+		assert.Equal(t, 20.0, AngularDifference(350, 10))
+		assert.Equal(t, 20.0, AngularDifference(10, 350))
+		assert.Equal(t, 180.0, AngularDifference(0, 180))
+		assert.Equal(t, 0.0, AngularDifference(-90, 270))
+	})
+
+	t.Run("summarizes source directions", func(t *testing.T) {
+		values := &WeatherSourceValues{ICON: float64Ptr(350), NOAA: float64Ptr(10), StormGlass: float64Ptr(0)}
+
+		stats, ok := values.DirectionStats()
+		require.True(t, ok)
+		assert.Equal(t, 3, stats.Count)
+		assert.InDelta(t, 0, AngularDifference(stats.Mean, 0), 1e-9)
+		assert.InDelta(t, 0, AngularDifference(stats.Median, 0), 1e-9)
+		assert.Equal(t, 350.0, stats.Min)
+		assert.Equal(t, 10.0, stats.Max)
+		assert.Equal(t, 20.0, stats.Spread)
+
+		_, ok = (*WeatherSourceValues)(nil).DirectionStats()
+		assert.False(t, ok)
+	})
+}
+
+func TestVector(t *testing.T) {
+	t.Run("points away from the direction", func(t *testing.T) {
+		north := NewVector(10, 0)
+		assert.InDelta(t, 0, north.U, 1e-9)
+		assert.InDelta(t, -10, north.V, 1e-9)
+
+		west := NewVector(5, 270)
+		assert.InDelta(t, 5, west.U, 1e-9)
+		assert.InDelta(t, 0, west.V, 1e-9)
+	})
+
+	t.Run("converts back to speed and direction", func(t *testing.T) {
+		for _, direction := range []float64{0, 45, 135, 200, 315} {
+			v := NewVector(7, direction)
+			assert.InDelta(t, 7, v.Speed(), 1e-9)
+			assert.InDelta(t, 0, AngularDifference(v.Direction(), direction), 1e-9, "%v°", direction)
+		}
+	})
+
+	t.Run("has no direction when calm", func(t *testing.T) {
+		calm := NewVector(0, 90)
+		assert.Equal(t, 0.0, calm.Speed())
+		assert.True(t, math.IsNaN(calm.Direction()))
+		assert.True(t, math.IsNaN(Vector{}.Direction()))
+	})
+
+	t.Run("combines the variables of an hour", func(t *testing.T) {
+		now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+		h := &Hour{
+			Time:          &now,
+			WindSpeed:     &WeatherSourceValues{ICON: float64Ptr(10), NOAA: float64Ptr(4)},
+			WindDirection: &WeatherSourceValues{ICON: float64Ptr(90)},
+		}
+
+		v, ok := h.Vector("windSpeed", "windDirection", SourceICON)
+		require.True(t, ok)
+		assert.InDelta(t, -10, v.U, 1e-9)
+		assert.InDelta(t, 0, v.V, 1e-9)
+
+		_, ok = h.Vector("windSpeed", "windDirection", SourceNOAA)
+		assert.False(t, ok, "expected a missing direction to fail")
+	})
+}
@@ -0,0 +1,47 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ElevationPointMeta represents the meta data from the elevation point request.
+type ElevationPointMeta struct {
+	Meta
+	Distance float64 `json:"distance,omitempty"`
+	Unit     string  `json:"unit,omitempty"`
+}
+
+// ElevationPointData represents the elevation of a grid cell. Negative values are
+// depths below sea level.
+type ElevationPointData struct {
+	Elevation float64 `json:"elevation"`
+}
+
+// ElevationPoint represents the elevation point request response.
+type ElevationPoint struct {
+	Data ElevationPointData `json:"data,omitempty"`
+	Meta ElevationPointMeta `json:"meta,omitempty"`
+}
+
+// ElevationPointRequestOptions represents the options for the elevation point request.
+type ElevationPointRequestOptions struct {
+	Lng float64 `json:"lng,omitempty"`
+	Lat float64 `json:"lat,omitempty"`
+}
+
+// GetElevationPoint sends an elevation point request: https://docs.stormglass.io/#/elevation?id=point-request
+func (c *Client) GetElevationPoint(ctx context.Context, options ElevationPointRequestOptions) (*ElevationPoint, error) {
+	values := url.Values{}
+	values.Add("lat", fmt.Sprintf("%f", options.Lat))
+	values.Add("lng", fmt.Sprintf("%f", options.Lng))
+
+	res := ElevationPoint{}
+
+	if err := c.get(ctx, EndpointElevationPoint, options, values, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,64 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetElevationPoint(t *testing.T) {
+	var (
+		testKey      = "testkey123"
+		endpointPath = "/elevation/point"
+		lat          = 58.7984
+		lng          = 17.8081
+	)
+
+	t.Run("test url composition and decoding", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Add("lat", fmt.Sprintf("%f", lat))
+			expectedValues.Add("lng", fmt.Sprintf("%f", lng))
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			want := `{
+				"data": {"elevation": -24.5},
+				"meta": {"cost": 1, "distance": 0.41, "unit": "m", "lat": 58.7984, "lng": 17.8081}
+			}`
+			if _, err := w.Write([]byte(want)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{
+			Lat: lat,
+			Lng: lng,
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+		assertion.Equal(-24.5, res.Data.Elevation)
+		assertion.Equal("m", res.Meta.Unit)
+		assertion.Equal(0.41, res.Meta.Distance)
+		assertion.Equal(1, res.Meta.Cost)
+		assertion.Equal(lat, res.Meta.Lat)
+	})
+}
@@ -0,0 +1,262 @@
+package stormglass
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Confidence rates how much the sources of a forecast agree.
+type Confidence string
+
+// Confidence levels.
+const (
+	ConfidenceLow    Confidence = "low"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceHigh   Confidence = "high"
+)
+
+// DefaultMinSources is the number of sources an Ensemble needs for a high confidence.
+const DefaultMinSources = 3
+
+// Ensemble analyses the agreement of the sources of weather points, treating
+// each source as a member of a multi-model ensemble.
+type Ensemble struct {
+	// Tolerances overrides the tolerance of variables by param name, e.g.
+	// "waveHeight". Sources within the tolerance of the median agree.
+	Tolerances map[string]float64
+	// MinSources is the number of sources needed for a high confidence,
+	// DefaultMinSources when zero.
+	MinSources int
+}
+
+// EnsembleStats holds the statistics of the sources of a variable in an hour.
+// Directions use circular statistics, wrapping around north.
+type EnsembleStats struct {
+	SourceStats
+	StdDev float64
+	// IQR is the inter-quartile range, the difference between the third and first quartiles.
+	IQR float64
+	// Agreement is the fraction of sources within the tolerance of the median.
+	Agreement  float64
+	Confidence Confidence
+}
+
+// EnsembleHour holds the statistics of the variables of an hour by param name.
+type EnsembleHour struct {
+	Time  time.Time
+	Stats map[string]EnsembleStats
+}
+
+// EnsembleAnalysis is the analysis of weather points.
+type EnsembleAnalysis struct {
+	Hours []EnsembleHour
+	// Confidence rates each variable by param name over all hours.
+	Confidence map[string]Confidence
+}
+
+// Analyze returns the statistics of every variable and hour of the points.
+func (e Ensemble) Analyze(points *Points) EnsembleAnalysis {
+	analysis := EnsembleAnalysis{Confidence: map[string]Confidence{}}
+	if points == nil {
+		return analysis
+	}
+
+	agreements := map[string][]float64{}
+	counts := map[string]int{}
+
+	for i := range points.Hours {
+		h := &points.Hours[i]
+		hour := EnsembleHour{Stats: map[string]EnsembleStats{}}
+
+		if h.Time != nil {
+			hour.Time = *h.Time
+		}
+
+		h.eachVariable(func(name string, values *WeatherSourceValues) {
+			stats, ok := e.AnalyzeValues(name, values)
+			if !ok {
+				return
+			}
+
+			hour.Stats[name] = stats
+			agreements[name] = append(agreements[name], stats.Agreement)
+
+			if count, seen := counts[name]; !seen || stats.Count < count {
+				counts[name] = stats.Count
+			}
+		})
+
+		analysis.Hours = append(analysis.Hours, hour)
+	}
+
+	for name, a := range agreements {
+		var sum float64
+		for _, v := range a {
+			sum += v
+		}
+
+		analysis.Confidence[name] = e.confidence(counts[name], sum/float64(len(a)))
+	}
+
+	return analysis
+}
+
+// AnalyzeValues returns the statistics of the values of the variable named
+// like its param, false when no source is present.
+func (e Ensemble) AnalyzeValues(name string, values *WeatherSourceValues) (EnsembleStats, bool) {
+	present := values.Values()
+	if len(present) == 0 {
+		return EnsembleStats{}, false
+	}
+
+	floats := make([]float64, len(present))
+	for i, v := range present {
+		floats[i] = v.Value
+	}
+
+	if isDirection(name) {
+		return e.analyzeDirections(name, floats), true
+	}
+
+	sort.Float64s(floats)
+
+	stats := EnsembleStats{SourceStats: newSourceStats(floats)}
+
+	var squares float64
+	for _, v := range floats {
+		squares += (v - stats.Mean) * (v - stats.Mean)
+	}
+
+	stats.StdDev = math.Sqrt(squares / float64(len(floats)))
+	stats.IQR = quantile(floats, 0.75) - quantile(floats, 0.25)
+
+	tolerance := e.tolerance(name, stats.Median)
+
+	agreeing := 0
+	for _, v := range floats {
+		if math.Abs(v-stats.Median) <= tolerance {
+			agreeing++
+		}
+	}
+
+	stats.Agreement = float64(agreeing) / float64(len(floats))
+	stats.Confidence = e.confidence(stats.Count, stats.Agreement)
+
+	return stats, true
+}
+
+// analyzeDirections returns the circular statistics of directions in degrees.
+// Sources agree when their angular difference to the median is within the
+// tolerance. When the directions cancel out, the circular standard deviation is
+// undefined and the one of the unwrapped directions is used instead.
+func (e Ensemble) analyzeDirections(name string, degrees []float64) EnsembleStats {
+	sourceStats, unwrapped := newDirectionStats(degrees)
+
+	stats := EnsembleStats{SourceStats: sourceStats}
+	stats.StdDev = CircularStdDev(degrees)
+
+	if math.IsNaN(stats.StdDev) {
+		unwrappedStats := newSourceStats(unwrapped)
+
+		var squares float64
+		for _, d := range unwrapped {
+			squares += (d - unwrappedStats.Mean) * (d - unwrappedStats.Mean)
+		}
+
+		stats.StdDev = math.Sqrt(squares / float64(len(unwrapped)))
+	}
+
+	stats.IQR = quantile(unwrapped, 0.75) - quantile(unwrapped, 0.25)
+
+	tolerance := e.tolerance(name, stats.Median)
+
+	agreeing := 0
+	for _, d := range degrees {
+		if AngularDifference(d, stats.Median) <= tolerance {
+			agreeing++
+		}
+	}
+
+	stats.Agreement = float64(agreeing) / float64(len(degrees))
+	stats.Confidence = e.confidence(stats.Count, stats.Agreement)
+
+	return stats
+}
+
+// confidence rates the agreement of count sources. A single source has a low
+// confidence, since there is nothing to compare it to.
+func (e Ensemble) confidence(count int, agreement float64) Confidence {
+	minSources := e.MinSources
+	if minSources <= 0 {
+		minSources = DefaultMinSources
+	}
+
+	switch {
+	case count < 2 || agreement < 0.5:
+		return ConfidenceLow
+	case count >= minSources && agreement >= 0.75:
+		return ConfidenceHigh
+	default:
+		return ConfidenceMedium
+	}
+}
+
+// agreementTolerance is the default tolerance of the params containing name.
+type agreementTolerance struct {
+	name      string
+	tolerance float64
+}
+
+// agreementTolerances returns the default tolerances of the params, matched in
+// order so more specific names come first. Units are the API ones, e.g. meters,
+// seconds, meters per second and degrees.
+func agreementTolerances() []agreementTolerance {
+	return []agreementTolerance{
+		{"Direction", 30},
+		{"Temperature", 1.5},
+		{"Height", 0.3},
+		{"Period", 1.5},
+		{"currentSpeed", 0.25},
+		{"Speed", 2},
+		{"gust", 3},
+		{"humidity", 10},
+		{"pressure", 2},
+		{"cloudCover", 20},
+		{"precipitation", 0.5},
+		{"visibility", 3},
+		{"iceCover", 0.1},
+		{"snowDepth", 0.05},
+		{"seaLevel", 0.2},
+	}
+}
+
+// tolerance returns the tolerance of the variable, by default a tenth of the median.
+func (e Ensemble) tolerance(name string, median float64) float64 {
+	if tolerance, ok := e.Tolerances[name]; ok {
+		return tolerance
+	}
+
+	for _, t := range agreementTolerances() {
+		if strings.Contains(name, t.name) {
+			return t.tolerance
+		}
+	}
+
+	return math.Abs(median) / 10
+}
+
+// quantile returns the q quantile of sorted values, linearly interpolated
+// between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+
+	return sorted[lower] + (pos-float64(lower))*(sorted[upper]-sorted[lower])
+}
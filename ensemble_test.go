@@ -0,0 +1,134 @@
+package stormglass
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsemble(t *testing.T) {
+	var (
+		first  = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+		second = first.Add(time.Hour)
+		points = &Points{Hours: []Hour{
+			{
+				Time: &first,
+				WaveHeight: &WeatherSourceValues{
+					ICON: float64Ptr(1.0), NOAA: float64Ptr(1.2), MeteoFrance: float64Ptr(1.1), StormGlass: float64Ptr(1.3),
+				},
+				WindSpeed:      &WeatherSourceValues{ICON: float64Ptr(4), NOAA: float64Ptr(10)},
+				AirTemperature: &WeatherSourceValues{StormGlass: float64Ptr(18)},
+			},
+			{
+				Time: &second,
+				WaveHeight: &WeatherSourceValues{
+					ICON: float64Ptr(1.0), NOAA: float64Ptr(2.0), MeteoFrance: float64Ptr(1.1), StormGlass: float64Ptr(1.2),
+				},
+			},
+		}}
+	)
+
+	t.Run("computes statistics per variable and hour", func(t *testing.T) {
+		analysis := Ensemble{}.Analyze(points)
+		require.Len(t, analysis.Hours, 2)
+		assert.Equal(t, first, analysis.Hours[0].Time)
+
+		wave := analysis.Hours[0].Stats["waveHeight"]
+		assert.Equal(t, 4, wave.Count)
+		assert.InDelta(t, 1.15, wave.Mean, 1e-9)
+		assert.InDelta(t, 1.15, wave.Median, 1e-9)
+		assert.InDelta(t, 0.1118, wave.StdDev, 1e-4)
+		assert.InDelta(t, 0.15, wave.IQR, 1e-9)
+		assert.InDelta(t, 0.3, wave.Spread, 1e-9)
+		assert.Equal(t, 1.0, wave.Agreement)
+		assert.Equal(t, ConfidenceHigh, wave.Confidence)
+
+		wind := analysis.Hours[0].Stats["windSpeed"]
+		assert.Equal(t, 0.0, wind.Agreement)
+		assert.Equal(t, ConfidenceLow, wind.Confidence)
+
+		temperature := analysis.Hours[0].Stats["airTemperature"]
+		assert.Equal(t, 0.0, temperature.StdDev)
+		assert.Equal(t, ConfidenceLow, temperature.Confidence, "expected a single source to have a low confidence")
+
+		wave = analysis.Hours[1].Stats["waveHeight"]
+		assert.Equal(t, 0.75, wave.Agreement)
+		assert.Equal(t, ConfidenceHigh, wave.Confidence)
+		assert.NotContains(t, analysis.Hours[1].Stats, "windSpeed")
+	})
+
+	t.Run("rates variables over all hours", func(t *testing.T) {
+		analysis := Ensemble{}.Analyze(points)
+		assert.Equal(t, map[string]Confidence{
+			"waveHeight":     ConfidenceHigh,
+			"windSpeed":      ConfidenceLow,
+			"airTemperature": ConfidenceLow,
+		}, analysis.Confidence)
+	})
+
+	t.Run("applies tolerances and min sources", func(t *testing.T) {
+		e := Ensemble{Tolerances: map[string]float64{"windSpeed": 3}, MinSources: 5}
+
+		stats, ok := e.AnalyzeValues("windSpeed", points.Hours[0].WindSpeed)
+		require.True(t, ok)
+		assert.Equal(t, 1.0, stats.Agreement)
+		assert.Equal(t, ConfidenceMedium, stats.Confidence)
+
+		stats, ok = e.AnalyzeValues("waveHeight", points.Hours[0].WaveHeight)
+		require.True(t, ok)
+		assert.Equal(t, ConfidenceMedium, stats.Confidence, "expected too few sources for a high confidence")
+
+		_, ok = e.AnalyzeValues("waveHeight", nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("wraps directions around north", func(t *testing.T) {
+		values := &WeatherSourceValues{
+			ICON: float64Ptr(350), NOAA: float64Ptr(355), MeteoFrance: float64Ptr(5), StormGlass: float64Ptr(180),
+		}
+
+		stats, ok := Ensemble{}.AnalyzeValues("windDirection", values)
+		require.True(t, ok)
+		assert.InDelta(t, 0, AngularDifference(stats.Median, 352.5), 1e-9)
+		assert.Equal(t, 0.75, stats.Agreement)
+		assert.Equal(t, ConfidenceHigh, stats.Confidence)
+		assert.Equal(t, 185.0, stats.Spread)
+	})
+
+	t.Run("handles opposing directions", func(t *testing.T) {
+		opposing := &Points{Hours: []Hour{{
+			Time:          &first,
+			WindDirection: &WeatherSourceValues{ICON: float64Ptr(90), NOAA: float64Ptr(270)},
+		}}}
+
+		analysis := Ensemble{}.Analyze(opposing)
+
+		stats := analysis.Hours[0].Stats["windDirection"]
+		assert.Equal(t, 90.0, stats.StdDev)
+		assert.Equal(t, 180.0, stats.Spread)
+		assert.Equal(t, ConfidenceLow, stats.Confidence)
+
+		_, err := json.Marshal(analysis)
+		assert.NoError(t, err)
+	})
+
+	t.Run("handles missing points", func(t *testing.T) {
+		analysis := Ensemble{}.Analyze(nil)
+		assert.Empty(t, analysis.Hours)
+		assert.Empty(t, analysis.Confidence)
+	})
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+	assert.Equal(t, 1.0, quantile(sorted, 0))
+	assert.Equal(t, 2.0, quantile(sorted, 0.25))
+	assert.Equal(t, 3.0, quantile(sorted, 0.5))
+	assert.Equal(t, 5.0, quantile(sorted, 1))
+	assert.Equal(t, 2.5, quantile([]float64{2, 3}, 0.5))
+	assert.True(t, math.IsNaN(quantile(nil, 0.5)), "expected NaN without values")
+}
@@ -2,16 +2,33 @@ package stormglass
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 )
 
+// Sentinel errors an Error can be matched against with errors.Is.
+var (
+	// ErrInvalidKey is returned when the API key is missing or invalid (401, 403).
+	ErrInvalidKey = errors.New("stormglass: invalid api key")
+	// ErrQuotaExceeded is returned when the daily quota is exceeded (402).
+	ErrQuotaExceeded = errors.New("stormglass: quota exceeded")
+	// ErrRateLimited is returned when too many requests are sent (429).
+	ErrRateLimited = errors.New("stormglass: rate limited")
+	// ErrInvalidParameters is returned when the request parameters are invalid (422).
+	ErrInvalidParameters = errors.New("stormglass: invalid parameters")
+	// ErrServer is returned when the API fails to handle the request (5xx).
+	ErrServer = errors.New("stormglass: server error")
+)
+
 // Error response representation.
 type Error struct {
 	StatusCode int                    `json:"-"`
 	Errors     map[string]interface{} `json:"errors"`
+	// Body holds the raw response body for debugging.
+	Body []byte `json:"-"`
 }
 
 // Error returns formatted errors as a string.
@@ -24,6 +41,52 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%d: %s", e.StatusCode, strings.Join(messages, ","))
 }
 
+// Is reports whether the error matches one of the sentinel errors by its status code.
+func (e Error) Is(target error) bool {
+	kind := e.kind()
+
+	return kind != nil && kind == target
+}
+
+// FieldErrors returns the error messages per request parameter, e.g. for an
+// ErrInvalidParameters error.
+func (e Error) FieldErrors() map[string][]string {
+	fields := make(map[string][]string, len(e.Errors))
+	for k, v := range e.Errors {
+		switch m := v.(type) {
+		case string:
+			fields[k] = []string{m}
+		case []string:
+			fields[k] = m
+		case []interface{}:
+			for _, i := range m {
+				fields[k] = append(fields[k], fmt.Sprint(i))
+			}
+		default:
+			fields[k] = []string{fmt.Sprint(m)}
+		}
+	}
+
+	return fields
+}
+
+func (e Error) kind() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrInvalidKey
+	case e.StatusCode == http.StatusPaymentRequired:
+		return ErrQuotaExceeded
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrInvalidParameters
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
 // NewError creates a new Error from an API response.
 func NewError(resp *http.Response) error {
 	apiErr := Error{
@@ -33,6 +96,7 @@ func NewError(resp *http.Response) error {
 
 	data, err := io.ReadAll(resp.Body)
 	if err == nil && data != nil {
+		apiErr.Body = data
 		if err = json.Unmarshal(data, &apiErr); err != nil {
 			apiErr.Errors["unknown"] = []string{"unknown_error_format"}
 		}
@@ -0,0 +1,73 @@
+package stormglass
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewError(t *testing.T) {
+	newResponse := func(status int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	t.Run("classifies status codes", func(t *testing.T) {
+		tests := []struct {
+			status int
+			want   error
+		}{
+			{http.StatusUnauthorized, ErrInvalidKey},
+			{http.StatusForbidden, ErrInvalidKey},
+			{http.StatusPaymentRequired, ErrQuotaExceeded},
+			{http.StatusTooManyRequests, ErrRateLimited},
+			{http.StatusUnprocessableEntity, ErrInvalidParameters},
+			{http.StatusInternalServerError, ErrServer},
+			{http.StatusServiceUnavailable, ErrServer},
+		}
+
+		for _, tt := range tests {
+			err := NewError(newResponse(tt.status, `{"errors":{}}`))
+			assert.ErrorIs(t, err, tt.want, "status %d", tt.status)
+		}
+	})
+
+	t.Run("does not classify unknown status codes", func(t *testing.T) {
+		err := NewError(newResponse(http.StatusNotFound, `{"errors":{}}`))
+
+		for _, sentinel := range []error{ErrInvalidKey, ErrQuotaExceeded, ErrRateLimited, ErrInvalidParameters, ErrServer} {
+			assert.False(t, errors.Is(err, sentinel))
+		}
+	})
+
+	t.Run("keeps raw body and field errors", func(t *testing.T) {
+		body := `{"errors":{"lat":["Invalid latitude"],"params":"Invalid parameter"}}`
+		err := NewError(newResponse(http.StatusUnprocessableEntity, body))
+
+		var apiErr *Error
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+		assert.Equal(t, []byte(body), apiErr.Body)
+		assert.Equal(t, map[string][]string{
+			"lat":    {"Invalid latitude"},
+			"params": {"Invalid parameter"},
+		}, apiErr.FieldErrors())
+	})
+
+	t.Run("unknown error format", func(t *testing.T) {
+		err := NewError(newResponse(http.StatusBadGateway, `<html>bad gateway</html>`))
+
+		var apiErr *Error
+		require.True(t, errors.As(err, &apiErr))
+		assert.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, "<html>bad gateway</html>", string(apiErr.Body))
+		assert.Equal(t, map[string][]string{"unknown": {"unknown_error_format"}}, apiErr.FieldErrors())
+	})
+}
@@ -33,6 +33,7 @@ func Test_GetExtremesPoint(t *testing.T) {
 		assert.Nil(t, res, "expecting nil response")
 		assert.NotNil(t, err, "expecting non-nil error")
 		assert.Equal(t, "403: key:API key is invalid", err.Error(), "unexpected error")
+		assert.ErrorIs(t, err, stormglass.ErrInvalidKey, "unexpected error")
 	})
 
 	t.Run("success: return 24 hour points", func(t *testing.T) {
@@ -76,5 +77,54 @@ func Test_GetExtremesPoint(t *testing.T) {
 			assert.NotNil(t, h.Type, "expected type to be set")
 		}
 	})
+}
+
+func Test_GetSeaLevelPoint(t *testing.T) {
+	t.Run("success: return 24 hour points", func(t *testing.T) {
+		tme := time.Now().In(time.UTC)
+		var start = now.New(tme).BeginningOfDay()
+		var end = now.New(tme).BeginningOfDay().Add(time.Hour * 23)
+
+		c := stormglass.NewClient(os.Getenv("STORMGLASS_API_KEY"))
+
+		ctx := context.Background()
+		res, err := c.GetSeaLevelPoint(ctx, stormglass.SeaLevelPointRequestOptions{
+			CommonRequestOptions: stormglass.CommonRequestOptions{
+				Lat:   lat,
+				Lng:   lng,
+				Start: &start,
+				End:   &end,
+			},
+			Datum: stormglass.MSL,
+		})
+
+		assert.Nil(t, err, "expecting nil response")
+		require.NotNil(t, res, "expecting non-nil response")
+		require.NotNil(t, res.Data, "expecting non-nil response data")
+		assert.Equal(t, 24, len(res.Data), "unexpected data count")
+		assert.Equal(t, stormglass.MSL, res.Meta.Datum, "unexpected meta value for datum")
+		assert.NotEqual(t, "", res.Meta.Station.Name, "expected some value for station name")
+	})
+}
+
+func Test_ListTideStationsInArea(t *testing.T) {
+	t.Run("success: return stations around the point", func(t *testing.T) {
+		c := stormglass.NewClient(os.Getenv("STORMGLASS_API_KEY"))
+
+		ctx := context.Background()
+		res, err := c.ListTideStationsInArea(ctx, stormglass.TideStationsAreaRequestOptions{
+			TopRightLat:   lat + 1,
+			TopRightLng:   lng + 1,
+			BottomLeftLat: lat - 1,
+			BottomLeftLng: lng - 1,
+		})
 
+		assert.Nil(t, err, "expecting nil response")
+		require.NotNil(t, res, "expecting non-nil response")
+		for _, s := range res.Data {
+			assert.NotEqual(t, "", s.Name, "expected some value for station name")
+			assert.NotEqual(t, 0, s.Lat, "expected some value for station lat")
+			assert.NotEqual(t, 0, s.Lng, "expected some value for station lng")
+		}
+	})
 }
@@ -42,6 +42,7 @@ func Test_GetPoint(t *testing.T) {
 		assert.Nil(t, res, "expecting nil response")
 		assert.NotNil(t, err, "expecting non-nil error")
 		assert.Equal(t, "403: key:API key is invalid", err.Error(), "unexpected error")
+		assert.ErrorIs(t, err, stormglass.ErrInvalidKey, "unexpected error")
 	})
 	t.Run("success: return 24 hour points", func(t *testing.T) {
 		tme := time.Now().In(time.UTC)
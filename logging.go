@@ -0,0 +1,57 @@
+package stormglass
+
+import (
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// logResponse emits a structured record of an endpoint call to the client Logger.
+func (c *Client) logResponse(info ResponseInfo) {
+	if c.Logger == nil {
+		return
+	}
+
+	args := []interface{}{
+		"endpoint", string(info.Endpoint),
+		"status", info.StatusCode,
+		"latency", info.Duration,
+		"attempts", info.Attempts,
+		"cached", info.Cached,
+	}
+
+	if lat := info.Query.Get("lat"); lat != "" {
+		args = append(args, "lat", lat, "lng", info.Query.Get("lng"))
+	}
+
+	if params := info.Query.Get("params"); params != "" {
+		args = append(args, "params", len(strings.Split(params, ",")))
+	}
+
+	// A cached response costs nothing and its Meta reports a past quota.
+	if info.Meta != nil && !info.Cached {
+		args = append(args, "cost", info.Meta.Cost)
+		if info.Meta.DailyQuota > 0 {
+			args = append(args, "remainingQuota", info.Meta.DailyQuota-info.Meta.RequestCount)
+		}
+	}
+
+	switch {
+	case info.Err != nil:
+		args = append(args, "error", c.redact(info.Err.Error()))
+		c.Logger.Error("stormglass request failed", args...)
+	case info.Cached:
+		c.Logger.Debug("stormglass request served from cache", args...)
+	default:
+		c.Logger.Info("stormglass request", args...)
+	}
+}
+
+// redact removes the API key from s.
+func (c *Client) redact(s string) string {
+	if c.apiKey == "" {
+		return s
+	}
+
+	return strings.ReplaceAll(s, c.apiKey, redacted)
+}
@@ -0,0 +1,100 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientLogging(t *testing.T) {
+	var testKey = "testkey123"
+
+	argsToMap := func(args []interface{}) map[string]interface{} {
+		m := map[string]interface{}{}
+		for i := 0; i+1 < len(args); i += 2 {
+			m[fmt.Sprint(args[i])] = args[i+1]
+		}
+
+		return m
+	}
+
+	t.Run("logs successful and cached requests", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"meta": {"cost": 1, "dailyQuota": 50, "requestCount": 8}}`))
+		}))
+		defer ts.Close()
+
+		logger := &testLogger{}
+		c := NewClient(testKey,
+			WithBaseURL(ts.URL),
+			WithHTTPClient(ts.Client()),
+			WithCache(NewMemoryCache(1)),
+			WithLogger(logger),
+		)
+
+		options := PointsRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{Lat: 1.5, Lng: 2.5},
+			Params:               WeatherParamsOptions{AirTemperature: true, WaveHeight: true},
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := c.GetPoint(context.Background(), options)
+			require.NoError(t, err)
+		}
+
+		require.Len(t, logger.records, 2)
+
+		record := logger.records[0]
+		assert.Equal(t, "info", record.level)
+		assert.Equal(t, "stormglass request", record.message)
+
+		args := argsToMap(record.args)
+		assert.Equal(t, "weather/point", args["endpoint"])
+		assert.Equal(t, "1.500000", args["lat"])
+		assert.Equal(t, "2.500000", args["lng"])
+		assert.Equal(t, 2, args["params"])
+		assert.Equal(t, http.StatusOK, args["status"])
+		assert.Equal(t, 1, args["cost"])
+		assert.Equal(t, 42, args["remainingQuota"])
+		assert.Contains(t, args, "latency")
+
+		assert.Equal(t, "debug", logger.records[1].level)
+
+		args = argsToMap(logger.records[1].args)
+		assert.Equal(t, true, args["cached"])
+		assert.NotContains(t, args, "cost")
+		assert.NotContains(t, args, "remainingQuota")
+	})
+
+	t.Run("logs failures with the api key redacted", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprintf(w, `{"errors": {"key": "API key %s is invalid"}}`, r.Header.Get("Authorization"))
+		}))
+		defer ts.Close()
+
+		logger := &testLogger{}
+		c := NewClient(testKey,
+			WithBaseURL(ts.URL),
+			WithHTTPClient(ts.Client()),
+			WithLogger(logger),
+		)
+
+		_, err := c.GetPoint(context.Background(), PointsRequestOptions{})
+		require.Error(t, err)
+
+		require.Len(t, logger.records, 1)
+		assert.Equal(t, "error", logger.records[0].level)
+
+		for _, arg := range logger.records[0].args {
+			assert.NotContains(t, fmt.Sprint(arg), testKey)
+		}
+
+		assert.Contains(t, argsToMap(logger.records[0].args)["error"], redacted)
+	})
+}
@@ -0,0 +1,106 @@
+package stormglass
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Middleware wraps the transport of every request sent by a Client, e.g. to
+// rewrite headers or rotate credentials. The endpoint of the request is
+// available through EndpointFromContext.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestInfo describes an endpoint call.
+type RequestInfo struct {
+	Endpoint Endpoint
+	// Options holds the options passed to the endpoint method.
+	Options interface{}
+	// Query holds the query values sent to the API.
+	Query url.Values
+}
+
+// ResponseInfo describes the outcome of an endpoint call.
+type ResponseInfo struct {
+	RequestInfo
+	// StatusCode is zero when no response was received.
+	StatusCode int
+	// Meta is nil when the call failed or the response has no meta data.
+	Meta     *Meta
+	Duration time.Duration
+	// Attempts is the number of requests sent, zero for cached responses.
+	Attempts int
+	Cached   bool
+	// CacheMiss is set when the response was looked up in the cache but not found.
+	CacheMiss bool
+	Err       error
+}
+
+// Hook observes every endpoint call of a Client. BeforeRequest may return a
+// derived context which is used for the request and passed to AfterResponse.
+type Hook interface {
+	BeforeRequest(ctx context.Context, info RequestInfo) context.Context
+	AfterResponse(ctx context.Context, info ResponseInfo)
+}
+
+// HookFuncs is a Hook calling its non nil functions.
+type HookFuncs struct {
+	Before func(ctx context.Context, info RequestInfo) context.Context
+	After  func(ctx context.Context, info ResponseInfo)
+}
+
+// BeforeRequest calls Before when set.
+func (h HookFuncs) BeforeRequest(ctx context.Context, info RequestInfo) context.Context {
+	if h.Before == nil {
+		return ctx
+	}
+
+	return h.Before(ctx, info)
+}
+
+// AfterResponse calls After when set.
+func (h HookFuncs) AfterResponse(ctx context.Context, info ResponseInfo) {
+	if h.After != nil {
+		h.After(ctx, info)
+	}
+}
+
+type endpointKey struct{}
+
+// EndpointFromContext returns the endpoint of the request the context belongs to.
+func EndpointFromContext(ctx context.Context) (Endpoint, bool) {
+	endpoint, ok := ctx.Value(endpointKey{}).(Endpoint)
+
+	return endpoint, ok
+}
+
+// httpClient returns the client HTTPClient with its transport wrapped by the
+// client middleware.
+func (c *Client) httpClient() *http.Client {
+	if len(c.Middleware) == 0 {
+		return c.HTTPClient
+	}
+
+	transport := c.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		transport = c.Middleware[i](transport)
+	}
+
+	client := *c.HTTPClient
+	client.Transport = transport
+
+	return &client
+}
@@ -0,0 +1,153 @@
+package stormglass
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMiddleware(t *testing.T) {
+	var testKey = "testkey123"
+
+	t.Run("wraps every request in order", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "rotated", r.Header.Get("Authorization"))
+			assert.Equal(t, "outer,inner", r.Header.Get("X-Order"))
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer ts.Close()
+
+		var endpoints []Endpoint
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.Middleware = []Middleware{
+			func(next http.RoundTripper) http.RoundTripper {
+				return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					endpoint, ok := EndpointFromContext(req.Context())
+					assert.True(t, ok)
+					endpoints = append(endpoints, endpoint)
+
+					req = req.Clone(req.Context())
+					req.Header.Set("X-Order", "outer")
+					return next.RoundTrip(req)
+				})
+			},
+			func(next http.RoundTripper) http.RoundTripper {
+				return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					req = req.Clone(req.Context())
+					req.Header.Set("X-Order", req.Header.Get("X-Order")+",inner")
+					req.Header.Set("Authorization", "rotated")
+					return next.RoundTrip(req)
+				})
+			},
+		}
+
+		ctx := context.Background()
+
+		_, err := c.GetPoint(ctx, PointsRequestOptions{})
+		require.NoError(t, err)
+
+		_, err = c.ListTideStations(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []Endpoint{EndpointWeatherPoint, EndpointTideStations}, endpoints)
+	})
+}
+
+func TestClientHooks(t *testing.T) {
+	var testKey = "testkey123"
+
+	type ctxKey struct{}
+
+	t.Run("observes successful and cached calls", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"meta": {"cost": 1, "requestCount": 4}}`))
+		}))
+		defer ts.Close()
+
+		var (
+			before []RequestInfo
+			after  []ResponseInfo
+		)
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.Cache = NewMemoryCache(1)
+		c.Middleware = []Middleware{
+			func(next http.RoundTripper) http.RoundTripper {
+				return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "value", req.Context().Value(ctxKey{}), "expected context of the hook")
+					return next.RoundTrip(req)
+				})
+			},
+		}
+		c.Hooks = []Hook{HookFuncs{
+			Before: func(ctx context.Context, info RequestInfo) context.Context {
+				before = append(before, info)
+				return context.WithValue(ctx, ctxKey{}, "value")
+			},
+			After: func(ctx context.Context, info ResponseInfo) {
+				assert.Equal(t, "value", ctx.Value(ctxKey{}))
+				after = append(after, info)
+			},
+		}}
+
+		options := ElevationPointRequestOptions{Lat: 1, Lng: 2}
+
+		for i := 0; i < 2; i++ {
+			_, err := c.GetElevationPoint(context.Background(), options)
+			require.NoError(t, err)
+		}
+
+		require.Len(t, before, 2)
+		require.Len(t, after, 2)
+
+		assert.Equal(t, EndpointElevationPoint, before[0].Endpoint)
+		assert.Equal(t, options, before[0].Options)
+		assert.Equal(t, "1.000000", before[0].Query.Get("lat"))
+
+		assert.Equal(t, http.StatusOK, after[0].StatusCode)
+		assert.Equal(t, 1, after[0].Attempts)
+		assert.False(t, after[0].Cached)
+		require.NotNil(t, after[0].Meta)
+		assert.Equal(t, 4, after[0].Meta.RequestCount)
+		assert.NoError(t, after[0].Err)
+
+		assert.True(t, after[1].Cached)
+		assert.Equal(t, 0, after[1].Attempts)
+	})
+
+	t.Run("observes failed calls", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"errors": {"key": "quota exceeded"}}`))
+		}))
+		defer ts.Close()
+
+		var after []ResponseInfo
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.Hooks = []Hook{HookFuncs{
+			After: func(ctx context.Context, info ResponseInfo) {
+				after = append(after, info)
+			},
+		}}
+
+		_, err := c.GetPoint(context.Background(), PointsRequestOptions{})
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+		require.Len(t, after, 1)
+		assert.Equal(t, http.StatusPaymentRequired, after[0].StatusCode)
+		assert.Nil(t, after[0].Meta)
+		assert.ErrorIs(t, after[0].Err, ErrQuotaExceeded)
+	})
+}
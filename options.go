@@ -0,0 +1,206 @@
+package stormglass
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(c *Client) error
+
+// Logger is the logger used by a Client. It is satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// WithBaseURL sets the base URL of the API, e.g. to use a proxy or a fake server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("base url: %w", err)
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("base url: %q is not an absolute http url", baseURL)
+		}
+
+		c.BaseURL = baseURL
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("http client: must not be nil")
+		}
+
+		c.HTTPClient = httpClient
+
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every request, one minute by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout: %s must be positive", timeout)
+		}
+
+		httpClient := *c.HTTPClient
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		if userAgent == "" {
+			return errors.New("user agent: must not be empty")
+		}
+
+		c.UserAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithRetryPolicy enables retries of transient failures.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		switch {
+		case policy == nil:
+			return errors.New("retry policy: must not be nil")
+		case policy.MaxAttempts < 1:
+			return fmt.Errorf("retry policy: max attempts %d must be at least 1", policy.MaxAttempts)
+		case policy.InitialBackoff < 0 || policy.MaxBackoff < 0:
+			return errors.New("retry policy: backoff must not be negative")
+		case policy.MaxBackoff > 0 && policy.MaxBackoff < policy.InitialBackoff:
+			return errors.New("retry policy: max backoff must not be less than initial backoff")
+		}
+
+		c.RetryPolicy = policy
+
+		return nil
+	}
+}
+
+// WithRateLimiter sets the limiter waited on before every request.
+func WithRateLimiter(limiter Limiter) Option {
+	return func(c *Client) error {
+		if limiter == nil {
+			return errors.New("rate limiter: must not be nil")
+		}
+
+		c.RateLimiter = limiter
+
+		return nil
+	}
+}
+
+// WithCache sets the response cache.
+func WithCache(cache Cache) Option {
+	return func(c *Client) error {
+		if cache == nil {
+			return errors.New("cache: must not be nil")
+		}
+
+		c.Cache = cache
+
+		return nil
+	}
+}
+
+// WithCacheTTL sets the time responses of the endpoint are cached for.
+func WithCacheTTL(endpoint Endpoint, ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl < 0 {
+			return fmt.Errorf("cache ttl: %s must not be negative", ttl)
+		}
+
+		if c.CacheTTL == nil {
+			c.CacheTTL = map[Endpoint]time.Duration{}
+		}
+
+		c.CacheTTL[endpoint] = ttl
+
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the client.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) error {
+		if logger == nil {
+			return errors.New("logger: must not be nil")
+		}
+
+		c.Logger = logger
+
+		return nil
+	}
+}
+
+// WithDefaultSources sets the weather sources requested by GetPoint when the
+// request options select none.
+func WithDefaultSources(sources WeatherSourcesOptions) Option {
+	return func(c *Client) error {
+		if len(sources.toList()) == 0 {
+			return errors.New("default sources: at least one source must be selected")
+		}
+
+		c.DefaultSources = sources
+
+		return nil
+	}
+}
+
+// WithUsageThreshold calls fn once a day when the used fraction of the daily
+// quota reaches threshold.
+func WithUsageThreshold(threshold float64, fn func(Usage)) Option {
+	return func(c *Client) error {
+		if threshold <= 0 || threshold > 1 {
+			return fmt.Errorf("usage threshold: %v must be in (0, 1]", threshold)
+		}
+
+		if fn == nil {
+			return errors.New("usage threshold: callback must not be nil")
+		}
+
+		c.UsageThreshold = threshold
+		c.OnUsageThreshold = fn
+
+		return nil
+	}
+}
+
+// WithMiddleware appends transport middleware.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) error {
+		c.Middleware = append(c.Middleware, middleware...)
+
+		return nil
+	}
+}
+
+// WithHooks appends hooks observing every endpoint call.
+func WithHooks(hooks ...Hook) Option {
+	return func(c *Client) error {
+		c.Hooks = append(c.Hooks, hooks...)
+
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package stormglass
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogRecord struct {
+	level   string
+	message string
+	args    []interface{}
+}
+
+type testLogger struct {
+	records []testLogRecord
+}
+
+func (l *testLogger) log(level, msg string, args []interface{}) {
+	l.records = append(l.records, testLogRecord{level: level, message: msg, args: args})
+}
+
+func (l *testLogger) messages() []string {
+	messages := make([]string, 0, len(l.records))
+	for _, r := range l.records {
+		messages = append(messages, r.message)
+	}
+
+	return messages
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) { l.log("debug", msg, args) }
+func (l *testLogger) Info(msg string, args ...interface{})  { l.log("info", msg, args) }
+func (l *testLogger) Warn(msg string, args ...interface{})  { l.log("warn", msg, args) }
+func (l *testLogger) Error(msg string, args ...interface{}) { l.log("error", msg, args) }
+
+func TestNewClientOptions(t *testing.T) {
+	var testKey = "testkey123"
+
+	t.Run("without options keeps the defaults", func(t *testing.T) {
+		c := NewClient(testKey)
+
+		assert.NoError(t, c.Err())
+		assert.Equal(t, BaseURLV2, c.BaseURL)
+		assert.Equal(t, time.Minute, c.HTTPClient.Timeout)
+		assert.Nil(t, c.RetryPolicy)
+		assert.Nil(t, c.RateLimiter)
+		assert.Nil(t, c.Cache)
+	})
+
+	t.Run("applies valid options", func(t *testing.T) {
+		httpClient := &http.Client{}
+		limiter := NewRateLimiter(1, 0)
+		cache := NewMemoryCache(1)
+		logger := &testLogger{}
+		policy := DefaultRetryPolicy()
+
+		c := NewClient(testKey,
+			WithBaseURL("http://localhost:8080/v2"),
+			WithHTTPClient(httpClient),
+			WithTimeout(time.Second),
+			WithUserAgent("test-agent"),
+			WithRetryPolicy(policy),
+			WithRateLimiter(limiter),
+			WithCache(cache),
+			WithCacheTTL(EndpointWeatherPoint, time.Minute),
+			WithLogger(logger),
+			WithDefaultSources(WeatherSourcesOptions{NOAA: true}),
+			WithUsageThreshold(0.9, func(Usage) {}),
+		)
+
+		require.NoError(t, c.Err())
+		assert.Equal(t, "http://localhost:8080/v2", c.BaseURL)
+		assert.Equal(t, time.Second, c.HTTPClient.Timeout)
+		assert.Equal(t, time.Duration(0), httpClient.Timeout, "expected the given client not to be changed")
+		assert.Equal(t, "test-agent", c.UserAgent)
+		assert.Equal(t, policy, c.RetryPolicy)
+		assert.Equal(t, limiter, c.RateLimiter)
+		assert.Equal(t, cache, c.Cache)
+		assert.Equal(t, time.Minute, c.CacheTTL[EndpointWeatherPoint])
+		assert.Equal(t, logger, c.Logger)
+		assert.True(t, c.DefaultSources.NOAA)
+		assert.Equal(t, 0.9, c.UsageThreshold)
+		assert.NotNil(t, c.OnUsageThreshold)
+	})
+
+	t.Run("reports invalid options", func(t *testing.T) {
+		invalid := map[string]Option{
+			"base url":     WithBaseURL("api.stormglass.io"),
+			"http client":  WithHTTPClient(nil),
+			"timeout":      WithTimeout(0),
+			"user agent":   WithUserAgent(""),
+			"retry policy": WithRetryPolicy(&RetryPolicy{}),
+			"retry backoff": WithRetryPolicy(&RetryPolicy{
+				MaxAttempts: 2, InitialBackoff: time.Second, MaxBackoff: time.Millisecond,
+			}),
+			"rate limiter":    WithRateLimiter(nil),
+			"cache":           WithCache(nil),
+			"cache ttl":       WithCacheTTL(EndpointWeatherPoint, -time.Second),
+			"logger":          WithLogger(nil),
+			"default sources": WithDefaultSources(WeatherSourcesOptions{}),
+			"usage threshold": WithUsageThreshold(2, func(Usage) {}),
+		}
+
+		for name, opt := range invalid {
+			c := NewClient(testKey, opt)
+			assert.Error(t, c.Err(), name)
+
+			_, err := c.GetPoint(context.Background(), PointsRequestOptions{})
+			assert.ErrorIs(t, err, c.Err(), name)
+		}
+	})
+
+	t.Run("sets user agent and default sources", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+			assert.Equal(t, "noaa", r.URL.Query().Get("source"))
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey,
+			WithBaseURL(ts.URL),
+			WithHTTPClient(ts.Client()),
+			WithUserAgent("test-agent"),
+			WithDefaultSources(WeatherSourcesOptions{NOAA: true}),
+		)
+		require.NoError(t, c.Err())
+
+		_, err := c.GetPoint(context.Background(), PointsRequestOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("logs retries", func(t *testing.T) {
+		var calls int
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer ts.Close()
+
+		logger := &testLogger{}
+		c := NewClient(testKey,
+			WithBaseURL(ts.URL),
+			WithHTTPClient(ts.Client()),
+			WithRetryPolicy(&RetryPolicy{MaxAttempts: 2}),
+			WithLogger(logger),
+		)
+		require.NoError(t, c.Err())
+
+		_, err := c.GetPoint(context.Background(), PointsRequestOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"retrying stormglass request", "stormglass request"}, logger.messages())
+	})
+}
@@ -0,0 +1,103 @@
+package stormglass
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrDailyLimitReached is returned by RateLimiter.Wait when the configured
+// requests per day are used up. The limit resets at UTC midnight.
+var ErrDailyLimitReached = errors.New("stormglass: daily request limit reached")
+
+// Limiter is waited on before every request sent by a Client. It is satisfied by
+// *RateLimiter and by golang.org/x/time/rate.Limiter.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimiter is a token bucket limiting the requests per second with an
+// optional cap on the requests per UTC day. It is safe for concurrent use, so a
+// single limiter can be shared by clients fanning out requests.
+type RateLimiter struct {
+	mu        sync.Mutex
+	perSecond float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+	perDay    int
+	today     int
+	resetAt   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing perSecond requests per second,
+// and perDay requests per day when perDay is greater than zero.
+func NewRateLimiter(perSecond float64, perDay int) *RateLimiter {
+	burst := math.Max(1, math.Floor(perSecond))
+
+	return &RateLimiter{
+		perSecond: perSecond,
+		burst:     burst,
+		tokens:    burst,
+		perDay:    perDay,
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := l.reserve(time.Now())
+		if err != nil || wait <= 0 {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token and returns zero, or returns the time to wait for the
+// next token.
+func (l *RateLimiter) reserve(now time.Time) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.resetAt.IsZero() || !now.Before(l.resetAt) {
+		y, m, d := now.UTC().Date()
+		l.resetAt = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+		l.today = 0
+	}
+
+	if l.perDay > 0 && l.today >= l.perDay {
+		return 0, ErrDailyLimitReached
+	}
+
+	if l.perSecond > 0 {
+		if !l.last.IsZero() {
+			l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.perSecond)
+		}
+		l.last = now
+
+		if l.tokens < 1 {
+			wait := time.Duration((1 - l.tokens) / l.perSecond * float64(time.Second))
+			if wait <= 0 {
+				wait = time.Nanosecond
+			}
+
+			return wait, nil
+		}
+
+		l.tokens--
+	}
+
+	l.today++
+
+	return 0, nil
+}
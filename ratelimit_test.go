@@ -0,0 +1,85 @@
+package stormglass
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	start := time.Date(2022, 6, 1, 15, 0, 0, 0, time.UTC)
+
+	t.Run("allows burst then waits for tokens", func(t *testing.T) {
+		l := NewRateLimiter(2, 0)
+
+		for i := 0; i < 2; i++ {
+			wait, err := l.reserve(start)
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(0), wait)
+		}
+
+		wait, err := l.reserve(start)
+		assert.NoError(t, err)
+		assert.Equal(t, 500*time.Millisecond, wait)
+
+		wait, err = l.reserve(start.Add(500 * time.Millisecond))
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+	})
+
+	t.Run("limits requests per day", func(t *testing.T) {
+		l := NewRateLimiter(0, 2)
+
+		for i := 0; i < 2; i++ {
+			_, err := l.reserve(start)
+			assert.NoError(t, err)
+		}
+
+		_, err := l.reserve(start)
+		assert.ErrorIs(t, err, ErrDailyLimitReached)
+
+		_, err = l.reserve(start.Add(9 * time.Hour))
+		assert.NoError(t, err)
+	})
+
+	t.Run("wait is context cancellable", func(t *testing.T) {
+		l := NewRateLimiter(0.01, 0)
+		assert.NoError(t, l.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	var testKey = "testkey123"
+
+	t.Run("every request waits on the limiter", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.RateLimiter = NewRateLimiter(100, 2)
+
+		ctx := context.Background()
+
+		_, err := c.GetPoint(ctx, PointsRequestOptions{})
+		assert.NoError(t, err)
+
+		_, err = c.GetExtremesPoint(ctx, ExtremesPointsRequestOptions{})
+		assert.NoError(t, err)
+
+		_, err = c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+		assert.ErrorIs(t, err, ErrDailyLimitReached)
+	})
+}
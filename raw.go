@@ -0,0 +1,42 @@
+package stormglass
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// RawResponse represents an undecoded response, e.g. to forward it as is.
+type RawResponse struct {
+	Body json.RawMessage
+	Meta Meta
+}
+
+// UnmarshalJSON keeps the body and decodes its meta data.
+func (r *RawResponse) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Meta Meta `json:"meta"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	r.Body = append(r.Body[:0], data...)
+	r.Meta = v.Meta
+
+	return nil
+}
+
+// GetRaw sends a request to the endpoint with the query values and returns the
+// undecoded response. It goes through the same retries, rate limiting, cache,
+// hooks and usage accounting as the typed methods.
+func (c *Client) GetRaw(ctx context.Context, endpoint Endpoint, values url.Values) (*RawResponse, error) {
+	res := RawResponse{}
+
+	if err := c.get(ctx, endpoint, nil, values, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,56 @@
+package stormglass
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetRaw(t *testing.T) {
+	var testKey = "testkey123"
+
+	t.Run("returns the undecoded body and its meta data", func(t *testing.T) {
+		want := `{"hours": [{"time": "2023-06-01T00:00:00+00:00", "waveHeight": {"sg": 1.2}}], ` +
+			`"meta": {"cost": 1, "dailyQuota": 10, "requestCount": 3}}`
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/weather/point", r.URL.Path)
+			assert.Equal(t, "lat=1&lng=2&params=waveHeight", r.URL.RawQuery)
+			assert.Equal(t, testKey, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(want))
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey, WithBaseURL(ts.URL), WithHTTPClient(ts.Client()))
+
+		res, err := c.GetRaw(context.Background(), EndpointWeatherPoint, url.Values{
+			"lat":    {"1"},
+			"lng":    {"2"},
+			"params": {"waveHeight"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, want, string(res.Body))
+		assert.Equal(t, 3, res.Meta.RequestCount)
+		assert.Equal(t, 3, c.Usage().RequestCount)
+		assert.Equal(t, 1, c.Usage().Requests)
+	})
+
+	t.Run("returns api errors", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors": {"lat": "Missing parameter"}}`))
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey, WithBaseURL(ts.URL), WithHTTPClient(ts.Client()))
+
+		res, err := c.GetRaw(context.Background(), EndpointWeatherPoint, url.Values{})
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
@@ -0,0 +1,129 @@
+package stormglass
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Resolver flattens weather points into one value per variable and hour, picking
+// the value of the most preferred source present.
+type Resolver struct {
+	// Priority lists the sources in order of preference, DefaultSourcePriority when empty.
+	Priority []Source
+	// Overrides lists the preferred sources of variables by param name, e.g.
+	// "swellHeight". They are tried before Priority.
+	Overrides map[string][]Source
+}
+
+// ResolvedHour holds the resolved values of an hour by param name, and the
+// source which supplied each of them.
+type ResolvedHour struct {
+	Time    time.Time          `json:"time"`
+	Values  map[string]float64 `json:"values"`
+	Sources map[string]Source  `json:"sources"`
+}
+
+// Resolve returns the resolved hours of the points.
+func (r Resolver) Resolve(points *Points) []ResolvedHour {
+	if points == nil {
+		return nil
+	}
+
+	hours := make([]ResolvedHour, 0, len(points.Hours))
+	for i := range points.Hours {
+		hours = append(hours, r.ResolveHour(&points.Hours[i]))
+	}
+
+	return hours
+}
+
+// ResolveHour returns the resolved values of the hour. Variables without any
+// value of the preferred sources are left out.
+func (r Resolver) ResolveHour(h *Hour) ResolvedHour {
+	resolved := ResolvedHour{
+		Values:  map[string]float64{},
+		Sources: map[string]Source{},
+	}
+
+	if h.Time != nil {
+		resolved.Time = *h.Time
+	}
+
+	h.eachVariable(func(name string, values *WeatherSourceValues) {
+		if v, ok := r.resolve(name, values); ok {
+			resolved.Values[name] = v.Value
+			resolved.Sources[name] = v.Source
+		}
+	})
+
+	return resolved
+}
+
+func (r Resolver) resolve(name string, values *WeatherSourceValues) (SourceValue, bool) {
+	if overrides := r.Overrides[name]; len(overrides) > 0 {
+		if v, ok := values.First(overrides...); ok {
+			return v, true
+		}
+	}
+
+	priority := r.Priority
+	if len(priority) == 0 {
+		priority = DefaultSourcePriority()
+	}
+
+	return values.First(priority...)
+}
+
+// Variable returns the values of the variable named like its param, e.g.
+// "waveHeight", nil when missing or unknown.
+func (h *Hour) Variable(name string) *WeatherSourceValues {
+	for _, f := range hourFields() {
+		if f.name == name {
+			return h.field(f)
+		}
+	}
+
+	return nil
+}
+
+// eachVariable calls fn with the name and values of every present variable of
+// the hour, in field order.
+func (h *Hour) eachVariable(fn func(name string, values *WeatherSourceValues)) {
+	for _, f := range hourFields() {
+		if values := h.field(f); values != nil {
+			fn(f.name, values)
+		}
+	}
+}
+
+func (h *Hour) field(f hourField) *WeatherSourceValues {
+	values, _ := reflect.ValueOf(h).Elem().Field(f.index).Interface().(*WeatherSourceValues)
+
+	return values
+}
+
+// hourField is a variable field of Hour.
+type hourField struct {
+	name  string
+	index int
+}
+
+// hourFields returns the variable fields of Hour named like their params.
+func hourFields() []hourField {
+	valuesType := reflect.TypeOf(&WeatherSourceValues{})
+	t := reflect.TypeOf(Hour{})
+
+	var fields []hourField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type != valuesType {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		fields = append(fields, hourField{name: name, index: i})
+	}
+
+	return fields
+}
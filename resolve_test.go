@@ -0,0 +1,79 @@
+package stormglass
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	var (
+		first  = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+		second = first.Add(time.Hour)
+		points = &Points{Hours: []Hour{
+			{
+				Time:          &first,
+				SwellHeight:   &WeatherSourceValues{NOAA: float64Ptr(1.2), StormGlass: float64Ptr(1.4)},
+				WindSpeed:     &WeatherSourceValues{DWD: float64Ptr(5), ICON: float64Ptr(6), StormGlass: float64Ptr(7)},
+				WindDirection: &WeatherSourceValues{ICON: float64Ptr(270)},
+			},
+			{
+				Time:        &second,
+				SwellHeight: &WeatherSourceValues{StormGlass: float64Ptr(1.5)},
+				WindSpeed:   &WeatherSourceValues{SMHI: float64Ptr(4)},
+			},
+		}}
+	)
+
+	t.Run("resolves by priority and overrides", func(t *testing.T) {
+		r := Resolver{
+			Overrides: map[string][]Source{
+				"swellHeight": {SourceNOAA},
+				"windSpeed":   {SourceDWD},
+			},
+		}
+
+		hours := r.Resolve(points)
+		require.Len(t, hours, 2)
+
+		assert.Equal(t, first, hours[0].Time)
+		assert.Equal(t, map[string]float64{"swellHeight": 1.2, "windSpeed": 5, "windDirection": 270}, hours[0].Values)
+		assert.Equal(t, map[string]Source{
+			"swellHeight":   SourceNOAA,
+			"windSpeed":     SourceDWD,
+			"windDirection": SourceICON,
+		}, hours[0].Sources)
+
+		// overridden sources fall back to the priority list when missing.
+		assert.Equal(t, second, hours[1].Time)
+		assert.Equal(t, map[string]float64{"swellHeight": 1.5, "windSpeed": 4}, hours[1].Values)
+		assert.Equal(t, map[string]Source{"swellHeight": SourceStormGlass, "windSpeed": SourceSMHI}, hours[1].Sources)
+	})
+
+	t.Run("leaves out variables without preferred sources", func(t *testing.T) {
+		r := Resolver{Priority: []Source{SourceICON}}
+
+		hour := r.ResolveHour(&points.Hours[1])
+		assert.Empty(t, hour.Values)
+		assert.Empty(t, hour.Sources)
+
+		hour = r.ResolveHour(&points.Hours[0])
+		assert.Equal(t, map[string]float64{"windSpeed": 6, "windDirection": 270}, hour.Values)
+	})
+
+	t.Run("handles missing points", func(t *testing.T) {
+		assert.Nil(t, Resolver{}.Resolve(nil))
+		assert.Empty(t, Resolver{}.ResolveHour(&Hour{}).Values)
+	})
+
+	t.Run("gets variables by param name", func(t *testing.T) {
+		h := points.Hours[0]
+		assert.Equal(t, h.SwellHeight, h.Variable("swellHeight"))
+		assert.Nil(t, h.Variable("waveHeight"))
+		assert.Nil(t, h.Variable("time"))
+		assert.Nil(t, h.Variable("unknown"))
+		assert.Len(t, hourFields(), 53)
+	})
+}
@@ -0,0 +1,187 @@
+package stormglass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures retries of idempotent requests failing with a network
+// error or a retryable status code.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff. Requests are not retried when
+	// the Retry-After header of the response asks to wait longer.
+	MaxBackoff time.Duration
+	// RetryableStatusCodes defaults to 429, 500, 502, 503 and 504 when empty.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a policy making up to three attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+	}
+}
+
+func (p *RetryPolicy) attempts(req *http.Request) int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return p.MaxAttempts
+	default:
+		return 1
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		}
+	}
+
+	for _, code := range codes {
+		if res.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the wait before the given retry, honoring the Retry-After
+// header of the failed response when present. It is false when Retry-After
+// exceeds MaxBackoff, so the request should not be retried.
+func (p *RetryPolicy) backoff(retry int, res *http.Response) (time.Duration, bool) {
+	if wait, ok := retryAfter(res); ok {
+		return wait, p.MaxBackoff <= 0 || wait <= p.MaxBackoff
+	}
+
+	wait := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if wait <= 0 {
+		return 0, true
+	}
+
+	// equal jitter: half of the backoff is fixed, the other half is random.
+	half := wait / 2
+	jitter := rand.Int63n(int64(wait-half) + 1) //nolint:gosec // jitter does not need a secure source
+
+	return half + time.Duration(jitter), true
+}
+
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func retryReason(res *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	return res.Status
+}
+
+// do sends the request once allowed by the client RateLimiter, retrying it
+// according to the client RetryPolicy. It returns the number of requests sent.
+func (c *Client) do(req *http.Request) (*http.Response, int, error) {
+	ctx := req.Context()
+	client := c.httpClient()
+	attempts := c.RetryPolicy.attempts(req)
+
+	for attempt := 1; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, attempt - 1, err
+			}
+		}
+
+		res, err := client.Do(req)
+		if attempt >= attempts || !c.RetryPolicy.shouldRetry(res, err) {
+			return res, attempt, err
+		}
+
+		wait, ok := c.RetryPolicy.backoff(attempt, res)
+		if !ok {
+			return res, attempt, err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return res, attempt, err
+		}
+
+		if c.Logger != nil {
+			endpoint, _ := EndpointFromContext(ctx)
+			c.Logger.Debug("retrying stormglass request",
+				"endpoint", string(endpoint),
+				"attempt", attempt,
+				"wait", wait,
+				"error", c.redact(retryReason(res, err)),
+			)
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package stormglass
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+	}
+
+	t.Run("grows exponentially with jitter", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			wait, ok := p.backoff(1, nil)
+			assert.True(t, ok)
+			assert.GreaterOrEqual(t, wait, 50*time.Millisecond)
+			assert.LessOrEqual(t, wait, 100*time.Millisecond)
+
+			wait, _ = p.backoff(2, nil)
+			assert.GreaterOrEqual(t, wait, 100*time.Millisecond)
+			assert.LessOrEqual(t, wait, 200*time.Millisecond)
+		}
+	})
+
+	t.Run("is capped by max backoff", func(t *testing.T) {
+		wait, ok := p.backoff(10, nil)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, wait, 150*time.Millisecond)
+		assert.LessOrEqual(t, wait, 300*time.Millisecond)
+	})
+
+	t.Run("honors retry after seconds", func(t *testing.T) {
+		res := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+		wait, ok := (&RetryPolicy{MaxBackoff: 5 * time.Second}).backoff(1, res)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Second, wait)
+
+		wait, ok = (&RetryPolicy{}).backoff(1, res)
+		assert.True(t, ok, "expected no limit without max backoff")
+		assert.Equal(t, 2*time.Second, wait)
+	})
+
+	t.Run("gives up when retry after exceeds max backoff", func(t *testing.T) {
+		res := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+		_, ok := p.backoff(1, res)
+		assert.False(t, ok)
+
+		at := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		res = &http.Response{Header: http.Header{"Retry-After": []string{at}}}
+		_, ok = p.backoff(1, res)
+		assert.False(t, ok)
+	})
+
+	t.Run("honors retry after date", func(t *testing.T) {
+		at := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		res := &http.Response{Header: http.Header{"Retry-After": []string{at}}}
+
+		wait, ok := (&RetryPolicy{MaxBackoff: 2 * time.Hour}).backoff(1, res)
+		assert.True(t, ok)
+		assert.Greater(t, wait, 59*time.Minute)
+	})
+}
+
+func TestClientRetries(t *testing.T) {
+	var testKey = "testkey123"
+
+	newServer := func(calls *int32, failures int32, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(calls, 1) <= failures {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"errors":{"error":"try again"}}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{}`))
+		}))
+	}
+
+	unavailable := func(calls *int32) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+	}
+
+	newClient := func(ts *httptest.Server) *Client {
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.RetryPolicy = &RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+		}
+
+		return c
+	}
+
+	t.Run("retries retryable status codes", func(t *testing.T) {
+		var calls int32
+		ts := newServer(&calls, 2, http.StatusServiceUnavailable)
+		defer ts.Close()
+
+		_, err := newClient(ts).GetElevationPoint(context.Background(), ElevationPointRequestOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		var calls int32
+		ts := newServer(&calls, 5, http.StatusTooManyRequests)
+		defer ts.Close()
+
+		_, err := newClient(ts).GetElevationPoint(context.Background(), ElevationPointRequestOptions{})
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("does not retry other status codes", func(t *testing.T) {
+		var calls int32
+		ts := newServer(&calls, 5, http.StatusUnauthorized)
+		defer ts.Close()
+
+		_, err := newClient(ts).GetElevationPoint(context.Background(), ElevationPointRequestOptions{})
+		assert.ErrorIs(t, err, ErrInvalidKey)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("does not retry non idempotent requests", func(t *testing.T) {
+		var calls int32
+		ts := newServer(&calls, 5, http.StatusServiceUnavailable)
+		defer ts.Close()
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, http.NoBody)
+		err := newClient(ts).sendRequest(req, nil)
+		assert.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("does not retry without policy", func(t *testing.T) {
+		var calls int32
+		ts := newServer(&calls, 5, http.StatusServiceUnavailable)
+		defer ts.Close()
+
+		c := newClient(ts)
+		c.RetryPolicy = nil
+
+		_, err := c.GetElevationPoint(context.Background(), ElevationPointRequestOptions{})
+		assert.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("stops when the backoff exceeds the context deadline", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(unavailable(&calls))
+		defer ts.Close()
+
+		c := newClient(ts)
+		c.RetryPolicy.InitialBackoff = time.Minute
+		c.RetryPolicy.MaxBackoff = time.Minute
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+		assert.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("stops when retry after exceeds max backoff", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		_, err := newClient(ts).GetElevationPoint(context.Background(), ElevationPointRequestOptions{})
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(unavailable(&calls))
+		defer ts.Close()
+
+		c := newClient(ts)
+		c.RetryPolicy.InitialBackoff = time.Minute
+		c.RetryPolicy.MaxBackoff = time.Minute
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,92 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SolarPoints represents a solar point request response.
+type SolarPoints struct {
+	Hours []SolarHour `json:"hours,omitempty"`
+	Meta  Meta        `json:"meta,omitempty"`
+}
+
+// SolarSourcesOptions : https://docs.stormglass.io/#/sources?id=available-sources
+type SolarSourcesOptions struct {
+	StormGlass bool
+}
+
+func (s SolarSourcesOptions) toList() []string {
+	var sources []string
+	if s.StormGlass {
+		sources = append(sources, "sg")
+	}
+
+	return sources
+}
+
+// SolarSourceValues represents potential solar source values response.
+type SolarSourceValues struct {
+	StormGlass *float64 `json:"sg,omitempty"`
+}
+
+// SolarHour represents an hour solar data point response.
+type SolarHour struct {
+	DownwardShortWaveRadiationFlux *SolarSourceValues `json:"downwardShortWaveRadiationFlux,omitempty"`
+	Time                           *time.Time         `json:"time,omitempty"`
+	UvIndex                        *SolarSourceValues `json:"uvIndex,omitempty"`
+}
+
+// SolarParamsOptions holds optional solar parameters.
+type SolarParamsOptions struct {
+	DownwardShortWaveRadiationFlux bool
+	UvIndex                        bool
+}
+
+func (p SolarParamsOptions) toList() []string {
+	return paramsToList(p)
+}
+
+// SolarPointRequestOptions for available solar query parameters.
+type SolarPointRequestOptions struct {
+	CommonRequestOptions
+	Params SolarParamsOptions  `json:"params,omitempty"`
+	Source SolarSourcesOptions `json:"sources,omitempty"`
+}
+
+// GetSolarPoint sends a solar point request https://docs.stormglass.io/#/solar?id=point-request.
+func (c *Client) GetSolarPoint(ctx context.Context, options SolarPointRequestOptions) (*SolarPoints, error) {
+	values := url.Values{}
+
+	values.Add("lat", fmt.Sprintf("%f", options.Lat))
+	values.Add("lng", fmt.Sprintf("%f", options.Lng))
+
+	params := options.Params.toList()
+	if len(params) > 0 {
+		values.Add("params", strings.Join(params, ","))
+	}
+
+	if options.Start != nil {
+		values.Add("start", fmt.Sprintf("%d", options.Start.Unix()))
+	}
+
+	if options.End != nil {
+		values.Add("end", fmt.Sprintf("%d", options.End.Unix()))
+	}
+
+	sources := options.Source.toList()
+	if len(sources) > 0 {
+		values.Add("source", strings.Join(sources, ","))
+	}
+
+	res := SolarPoints{}
+
+	if err := c.get(ctx, EndpointSolarPoint, options, values, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
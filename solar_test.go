@@ -0,0 +1,122 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolarParamOptionsToList(t *testing.T) {
+	t.Run("with no options", func(t *testing.T) {
+		var options SolarParamsOptions
+		list := options.toList()
+
+		assert.Len(t, list, 0)
+	})
+
+	t.Run("with all options", func(t *testing.T) {
+		options := SolarParamsOptions{
+			DownwardShortWaveRadiationFlux: true,
+			UvIndex:                        true,
+		}
+
+		assert.Equal(t, []string{"downwardShortWaveRadiationFlux", "uvIndex"}, options.toList())
+	})
+}
+
+func TestClient_GetSolarPoint(t *testing.T) {
+	var (
+		start        = now.BeginningOfDay()
+		end          = now.EndOfDay()
+		testKey      = "testkey123"
+		endpointPath = "/solar/point"
+		lat          = 58.7984
+		lng          = 17.8081
+	)
+
+	t.Run("test full url composition", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Set("lat", fmt.Sprintf("%f", lat))
+			expectedValues.Set("lng", fmt.Sprintf("%f", lng))
+			expectedValues.Set("start", fmt.Sprintf("%d", start.Unix()))
+			expectedValues.Set("end", fmt.Sprintf("%d", end.Unix()))
+			expectedValues.Set("params", "uvIndex")
+			expectedValues.Set("source", "sg")
+
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			_, _ = fmt.Fprintln(w, "{}")
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetSolarPoint(ctx, SolarPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat:   lat,
+				Lng:   lng,
+				Start: &start,
+				End:   &end,
+			},
+			Params: SolarParamsOptions{
+				UvIndex: true,
+			},
+			Source: SolarSourcesOptions{
+				StormGlass: true,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+	})
+
+	t.Run("test response decoding", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := `{
+				"hours": [
+					{"time": "2022-06-01T12:00:00+00:00", "uvIndex": {"sg": 5.4}, "downwardShortWaveRadiationFlux": {"sg": 812.3}}
+				],
+				"meta": {"cost": 1}
+			}`
+			if _, err := w.Write([]byte(want)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetSolarPoint(ctx, SolarPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat: lat,
+				Lng: lng,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.Len(res.Hours, 1)
+		assertion.Equal(5.4, *res.Hours[0].UvIndex.StormGlass)
+		assertion.Equal(812.3, *res.Hours[0].DownwardShortWaveRadiationFlux.StormGlass)
+	})
+}
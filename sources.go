@@ -0,0 +1,192 @@
+package stormglass
+
+import (
+	"math"
+	"sort"
+)
+
+// Source identifies a weather data source by its API name.
+type Source string
+
+// Weather sources: https://docs.stormglass.io/#/sources?id=available-sources
+const (
+	SourceICON        Source = "icon"
+	SourceDWD         Source = "dwd"
+	SourceNOAA        Source = "noaa"
+	SourceMeteoFrance Source = "meteo"
+	SourceUKMetOffice Source = "meto"
+	SourceFCOO        Source = "fcoo"
+	SourceFMI         Source = "fmi"
+	SourceYR          Source = "yr"
+	SourceSMHI        Source = "smhi"
+	SourceStormGlass  Source = "sg"
+)
+
+// DefaultSourcePriority returns the weather sources in the order they are
+// preferred by default: the Stormglass blend first, then global models before
+// regional ones.
+func DefaultSourcePriority() []Source {
+	return []Source{
+		SourceStormGlass,
+		SourceICON,
+		SourceDWD,
+		SourceNOAA,
+		SourceMeteoFrance,
+		SourceUKMetOffice,
+		SourceFCOO,
+		SourceFMI,
+		SourceYR,
+		SourceSMHI,
+	}
+}
+
+// SourceValue is the value of a single source.
+type SourceValue struct {
+	Source Source
+	Value  float64
+}
+
+// SourceStats summarizes the values of the sources of a variable.
+type SourceStats struct {
+	Count  int
+	Mean   float64
+	Median float64
+	Min    float64
+	Max    float64
+	// Spread is the difference between Max and Min.
+	Spread float64
+}
+
+// Get returns the value of the source, false when missing. It is safe to call
+// on a nil WeatherSourceValues, e.g. a variable missing from an Hour.
+func (v *WeatherSourceValues) Get(source Source) (float64, bool) {
+	p := v.value(source)
+	if p == nil {
+		return 0, false
+	}
+
+	return *p, true
+}
+
+// Values returns the values of the present sources in DefaultSourcePriority order.
+func (v *WeatherSourceValues) Values() []SourceValue {
+	var values []SourceValue
+	for _, source := range DefaultSourcePriority() {
+		if value, ok := v.Get(source); ok {
+			values = append(values, SourceValue{Source: source, Value: value})
+		}
+	}
+
+	return values
+}
+
+// First returns the value of the first present source of the preference list,
+// or of DefaultSourcePriority without preference. It is false when none of the
+// sources is present.
+func (v *WeatherSourceValues) First(preference ...Source) (SourceValue, bool) {
+	if len(preference) == 0 {
+		preference = DefaultSourcePriority()
+	}
+
+	for _, source := range preference {
+		if value, ok := v.Get(source); ok {
+			return SourceValue{Source: source, Value: value}, true
+		}
+	}
+
+	return SourceValue{}, false
+}
+
+// Stats returns statistics of the values of the present sources, false when
+// none is present. Use DirectionStats for directions.
+func (v *WeatherSourceValues) Stats() (SourceStats, bool) {
+	values := v.Values()
+	if len(values) == 0 {
+		return SourceStats{}, false
+	}
+
+	floats := make([]float64, len(values))
+	for i, value := range values {
+		floats[i] = value.Value
+	}
+
+	return newSourceStats(floats), true
+}
+
+// value returns the value of the source, nil when missing or unknown.
+func (v *WeatherSourceValues) value(source Source) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	if p := v.field(source); p != nil {
+		return *p
+	}
+
+	return nil
+}
+
+// field returns the address of the field of the source, nil when unknown.
+func (v *WeatherSourceValues) field(source Source) **float64 {
+	switch source {
+	case SourceICON:
+		return &v.ICON
+	case SourceDWD:
+		return &v.DWD
+	case SourceNOAA:
+		return &v.NOAA
+	case SourceMeteoFrance:
+		return &v.MeteoFrance
+	case SourceUKMetOffice:
+		return &v.UKMetOffice
+	case SourceFCOO:
+		return &v.FCOO
+	case SourceFMI:
+		return &v.FMI
+	case SourceYR:
+		return &v.YR
+	case SourceSMHI:
+		return &v.SMHI
+	case SourceStormGlass:
+		return &v.StormGlass
+	default:
+		return nil
+	}
+}
+
+// newSourceStats returns the statistics of values, which must not be empty.
+func newSourceStats(values []float64) SourceStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	stats := SourceStats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+	}
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+
+	stats.Mean = sum / float64(len(sorted))
+	stats.Median = median(sorted)
+	stats.Spread = stats.Max - stats.Min
+
+	return stats
+}
+
+// median returns the median of sorted values, NaN when empty.
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,86 @@
+package stormglass
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestWeatherSourceValues(t *testing.T) {
+	values := &WeatherSourceValues{
+		NOAA:       float64Ptr(1.5),
+		ICON:       float64Ptr(1.0),
+		SMHI:       float64Ptr(3.5),
+		StormGlass: float64Ptr(2.0),
+	}
+
+	t.Run("gets values by source", func(t *testing.T) {
+		v, ok := values.Get(SourceNOAA)
+		assert.True(t, ok)
+		assert.Equal(t, 1.5, v)
+
+		_, ok = values.Get(SourceDWD)
+		assert.False(t, ok)
+
+		_, ok = values.Get(Source("unknown"))
+		assert.False(t, ok)
+
+		for _, source := range DefaultSourcePriority() {
+			assert.NotPanics(t, func() {
+				empty := &WeatherSourceValues{}
+				_, ok = empty.Get(source)
+				assert.False(t, ok, source)
+			})
+		}
+	})
+
+	t.Run("lists present values in priority order", func(t *testing.T) {
+		assert.Equal(t, []SourceValue{
+			{SourceStormGlass, 2.0},
+			{SourceICON, 1.0},
+			{SourceNOAA, 1.5},
+			{SourceSMHI, 3.5},
+		}, values.Values())
+	})
+
+	t.Run("returns the first value by preference", func(t *testing.T) {
+		v, ok := values.First(SourceDWD, SourceSMHI, SourceNOAA)
+		assert.True(t, ok)
+		assert.Equal(t, SourceValue{SourceSMHI, 3.5}, v)
+
+		v, ok = values.First()
+		assert.True(t, ok)
+		assert.Equal(t, SourceValue{SourceStormGlass, 2.0}, v)
+
+		_, ok = values.First(SourceDWD, SourceYR)
+		assert.False(t, ok)
+	})
+
+	t.Run("computes statistics", func(t *testing.T) {
+		stats, ok := values.Stats()
+		assert.True(t, ok)
+		assert.Equal(t, SourceStats{Count: 4, Mean: 2, Median: 1.75, Min: 1, Max: 3.5, Spread: 2.5}, stats)
+
+		stats, ok = (&WeatherSourceValues{YR: float64Ptr(4)}).Stats()
+		assert.True(t, ok)
+		assert.Equal(t, SourceStats{Count: 1, Mean: 4, Median: 4, Min: 4, Max: 4}, stats)
+	})
+
+	t.Run("is safe on missing variables", func(t *testing.T) {
+		var missing *WeatherSourceValues
+
+		_, ok := missing.Get(SourceStormGlass)
+		assert.False(t, ok)
+		assert.Empty(t, missing.Values())
+
+		_, ok = missing.First()
+		assert.False(t, ok)
+
+		_, ok = missing.Stats()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,101 @@
+// Package stormglassotel traces Stormglass API calls with OpenTelemetry.
+package stormglassotel
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/yawlhead91/stormglassgo/stormglassotel"
+
+// Hook starts a client span for every endpoint call of a stormglass.Client.
+type Hook struct {
+	tracer trace.Tracer
+}
+
+// NewHook returns a Hook creating spans with a tracer of the provider.
+func NewHook(provider trace.TracerProvider) *Hook {
+	return &Hook{
+		tracer: provider.Tracer(instrumentationName),
+	}
+}
+
+// WithTracing returns a client option tracing every endpoint call.
+func WithTracing(provider trace.TracerProvider) stormglass.Option {
+	return stormglass.WithHooks(NewHook(provider))
+}
+
+// BeforeRequest starts the span of the call as a child of the caller's span.
+func (h *Hook) BeforeRequest(ctx context.Context, info stormglass.RequestInfo) context.Context {
+	ctx, _ = h.tracer.Start(ctx, "stormglass "+string(info.Endpoint),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(requestAttributes(info)...),
+	)
+
+	return ctx
+}
+
+// AfterResponse records the outcome of the call and ends its span.
+func (h *Hook) AfterResponse(ctx context.Context, info stormglass.ResponseInfo) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("http.status_code", info.StatusCode),
+		attribute.Int("stormglass.attempts", info.Attempts),
+		attribute.Bool("stormglass.cached", info.Cached),
+	)
+
+	// A cached response costs nothing and its Meta reports a past quota.
+	if info.Meta != nil && !info.Cached {
+		span.SetAttributes(
+			attribute.Int("stormglass.cost", info.Meta.Cost),
+			attribute.Int("stormglass.request_count", info.Meta.RequestCount),
+			attribute.Int("stormglass.daily_quota", info.Meta.DailyQuota),
+		)
+	}
+
+	if info.Err != nil {
+		span.RecordError(info.Err)
+		span.SetStatus(codes.Error, info.Err.Error())
+	}
+}
+
+func requestAttributes(info stormglass.RequestInfo) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("stormglass.endpoint", string(info.Endpoint)),
+	}
+
+	for _, key := range []string{"lat", "lng"} {
+		if v, err := strconv.ParseFloat(info.Query.Get(key), 64); err == nil {
+			attrs = append(attrs, attribute.Float64("stormglass."+key, v))
+		}
+	}
+
+	for _, key := range []string{"start", "end"} {
+		if v, err := strconv.ParseInt(info.Query.Get(key), 10, 64); err == nil {
+			attrs = append(attrs, attribute.String("stormglass."+key, time.Unix(v, 0).UTC().Format(time.RFC3339)))
+		}
+	}
+
+	if params := info.Query.Get("params"); params != "" {
+		attrs = append(attrs, attribute.StringSlice("stormglass.params", strings.Split(params, ",")))
+	}
+
+	if sources := info.Query.Get("source"); sources != "" {
+		attrs = append(attrs, attribute.StringSlice("stormglass.sources", strings.Split(sources, ",")))
+	}
+
+	if datum := info.Query.Get("datum"); datum != "" {
+		attrs = append(attrs, attribute.String("stormglass.datum", datum))
+	}
+
+	return attrs
+}
@@ -0,0 +1,129 @@
+package stormglassotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stormglass "github.com/yawlhead91/stormglassgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHook(t *testing.T) {
+	var testKey = "testkey123"
+
+	newClient := func(
+		t *testing.T, status int, body string, opts ...stormglass.Option,
+	) (*stormglass.Client, *tracetest.SpanRecorder, func()) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+		c := stormglass.NewClient(testKey, append([]stormglass.Option{
+			stormglass.WithBaseURL(ts.URL),
+			stormglass.WithHTTPClient(ts.Client()),
+			WithTracing(provider),
+		}, opts...)...)
+		require.NoError(t, c.Err())
+
+		return c, recorder, ts.Close
+	}
+
+	attributes := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		m := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			m[kv.Key] = kv.Value
+		}
+
+		return m
+	}
+
+	t.Run("traces successful calls as child spans", func(t *testing.T) {
+		c, recorder, closeServer := newClient(t, http.StatusOK, `{"meta": {"cost": 1, "requestCount": 3, "dailyQuota": 10}}`)
+		defer closeServer()
+
+		parentProvider := sdktrace.NewTracerProvider()
+		ctx, parent := parentProvider.Tracer("test").Start(context.Background(), "parent")
+
+		start := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+		_, err := c.GetPoint(ctx, stormglass.PointsRequestOptions{
+			CommonRequestOptions: stormglass.CommonRequestOptions{
+				Lat:   1.5,
+				Lng:   2.5,
+				Start: &start,
+			},
+			Params: stormglass.WeatherParamsOptions{AirTemperature: true},
+			Source: stormglass.WeatherSourcesOptions{NOAA: true, ICON: true},
+		})
+		require.NoError(t, err)
+		parent.End()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+
+		span := spans[0]
+		assert.Equal(t, "stormglass weather/point", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().TraceID(), span.Parent().TraceID())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+
+		attrs := attributes(span)
+		assert.Equal(t, "weather/point", attrs["stormglass.endpoint"].AsString())
+		assert.Equal(t, 1.5, attrs["stormglass.lat"].AsFloat64())
+		assert.Equal(t, 2.5, attrs["stormglass.lng"].AsFloat64())
+		assert.Equal(t, "2022-06-01T00:00:00Z", attrs["stormglass.start"].AsString())
+		assert.Equal(t, []string{"airTemperature"}, attrs["stormglass.params"].AsStringSlice())
+		assert.Equal(t, []string{"icon", "noaa"}, attrs["stormglass.sources"].AsStringSlice())
+		assert.Equal(t, int64(http.StatusOK), attrs["http.status_code"].AsInt64())
+		assert.Equal(t, int64(1), attrs["stormglass.cost"].AsInt64())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("leaves the cost out of cached calls", func(t *testing.T) {
+		c, recorder, closeServer := newClient(t, http.StatusOK, `{"meta": {"cost": 1, "requestCount": 3, "dailyQuota": 10}}`,
+			stormglass.WithCache(stormglass.NewMemoryCache(1)),
+		)
+		defer closeServer()
+
+		for i := 0; i < 2; i++ {
+			_, err := c.GetPoint(context.Background(), stormglass.PointsRequestOptions{})
+			require.NoError(t, err)
+		}
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		attrs := attributes(spans[1])
+		assert.True(t, attrs["stormglass.cached"].AsBool())
+
+		for _, key := range []attribute.Key{"stormglass.cost", "stormglass.request_count", "stormglass.daily_quota"} {
+			assert.NotContains(t, attrs, key)
+		}
+	})
+
+	t.Run("records errors", func(t *testing.T) {
+		c, recorder, closeServer := newClient(t, http.StatusPaymentRequired, `{"errors": {"key": "quota exceeded"}}`)
+		defer closeServer()
+
+		_, err := c.GetElevationPoint(context.Background(), stormglass.ElevationPointRequestOptions{})
+		require.ErrorIs(t, err, stormglass.ErrQuotaExceeded)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, int64(http.StatusPaymentRequired), attributes(spans[0])["http.status_code"].AsInt64())
+		assert.Len(t, spans[0].Events(), 1, "expected the error to be recorded")
+	})
+}
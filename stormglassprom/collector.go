@@ -0,0 +1,128 @@
+// Package stormglassprom exposes Stormglass client metrics to Prometheus.
+package stormglassprom
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+const namespace = "stormglass"
+
+// Collector is a stormglass.Hook recording metrics of every endpoint call, and a
+// prometheus.Collector exposing them.
+type Collector struct {
+	requests     *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+	retries      *prometheus.CounterVec
+	cacheHits    *prometheus.CounterVec
+	cacheMisses  *prometheus.CounterVec
+	dailyQuota   prometheus.Gauge
+	requestCount prometheus.Gauge
+}
+
+// NewCollector returns a Collector to register with a prometheus.Registerer and
+// to pass to the client with stormglass.WithHooks.
+func NewCollector() *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of endpoint calls by endpoint and HTTP status, 0 when no response was received.",
+		}, []string{"endpoint", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of endpoint calls including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried requests.",
+		}, []string{"endpoint"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_hits_total",
+			Help:      "Number of endpoint calls served from the cache.",
+		}, []string{"endpoint"}),
+		cacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_misses_total",
+			Help:      "Number of endpoint calls not found in the cache.",
+		}, []string{"endpoint"}),
+		dailyQuota: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "daily_quota",
+			Help:      "Last daily quota reported by the API.",
+		}),
+		requestCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "request_count",
+			Help:      "Last count of requests made today reported by the API.",
+		}),
+	}
+}
+
+// BeforeRequest implements stormglass.Hook.
+func (c *Collector) BeforeRequest(ctx context.Context, _ stormglass.RequestInfo) context.Context {
+	return ctx
+}
+
+// AfterResponse implements stormglass.Hook.
+func (c *Collector) AfterResponse(_ context.Context, info stormglass.ResponseInfo) {
+	endpoint := string(info.Endpoint)
+
+	c.requests.WithLabelValues(endpoint, strconv.Itoa(info.StatusCode)).Inc()
+	c.latency.WithLabelValues(endpoint).Observe(info.Duration.Seconds())
+
+	if info.Attempts > 1 {
+		c.retries.WithLabelValues(endpoint).Add(float64(info.Attempts - 1))
+	}
+
+	if info.Cached {
+		c.cacheHits.WithLabelValues(endpoint).Inc()
+	}
+
+	if info.CacheMiss {
+		c.cacheMisses.WithLabelValues(endpoint).Inc()
+	}
+
+	if info.Meta != nil && !info.Cached {
+		if info.Meta.DailyQuota > 0 {
+			c.dailyQuota.Set(float64(info.Meta.DailyQuota))
+		}
+
+		if info.Meta.RequestCount > 0 {
+			c.requestCount.Set(float64(info.Meta.RequestCount))
+		}
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.collectors() {
+		m.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.collectors() {
+		m.Collect(ch)
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.requests,
+		c.latency,
+		c.retries,
+		c.cacheHits,
+		c.cacheMisses,
+		c.dailyQuota,
+		c.requestCount,
+	}
+}
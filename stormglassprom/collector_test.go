@@ -0,0 +1,96 @@
+package stormglassprom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+func TestCollector(t *testing.T) {
+	var testKey = "testkey123"
+
+	t.Run("records calls, retries, cache and quota", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			if r.URL.Query().Get("lat") == "9.000000" {
+				w.WriteHeader(http.StatusPaymentRequired)
+				_, _ = w.Write([]byte(`{"errors": {"key": "quota exceeded"}}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"meta": {"cost": 1, "dailyQuota": 50, "requestCount": 7}}`))
+		}))
+		defer ts.Close()
+
+		collector := NewCollector()
+		registry := prometheus.NewPedanticRegistry()
+		require.NoError(t, registry.Register(collector))
+
+		c := stormglass.NewClient(testKey,
+			stormglass.WithBaseURL(ts.URL),
+			stormglass.WithHTTPClient(ts.Client()),
+			stormglass.WithRetryPolicy(&stormglass.RetryPolicy{MaxAttempts: 2}),
+			stormglass.WithCache(stormglass.NewMemoryCache(10)),
+			stormglass.WithHooks(collector),
+		)
+		require.NoError(t, c.Err())
+
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			_, err := c.GetElevationPoint(ctx, stormglass.ElevationPointRequestOptions{})
+			require.NoError(t, err)
+		}
+
+		_, err := c.GetElevationPoint(ctx, stormglass.ElevationPointRequestOptions{Lat: 9})
+		require.ErrorIs(t, err, stormglass.ErrQuotaExceeded)
+
+		expected := `
+# HELP stormglass_cache_hits_total Number of endpoint calls served from the cache.
+# TYPE stormglass_cache_hits_total counter
+stormglass_cache_hits_total{endpoint="elevation/point"} 1
+# HELP stormglass_cache_misses_total Number of endpoint calls not found in the cache.
+# TYPE stormglass_cache_misses_total counter
+stormglass_cache_misses_total{endpoint="elevation/point"} 2
+# HELP stormglass_daily_quota Last daily quota reported by the API.
+# TYPE stormglass_daily_quota gauge
+stormglass_daily_quota 50
+# HELP stormglass_request_count Last count of requests made today reported by the API.
+# TYPE stormglass_request_count gauge
+stormglass_request_count 7
+# HELP stormglass_requests_total Number of endpoint calls by endpoint and HTTP status, 0 when no response was received.
+# TYPE stormglass_requests_total counter
+stormglass_requests_total{endpoint="elevation/point",status="200"} 2
+stormglass_requests_total{endpoint="elevation/point",status="402"} 1
+# HELP stormglass_retries_total Number of retried requests.
+# TYPE stormglass_retries_total counter
+stormglass_retries_total{endpoint="elevation/point"} 1
+`
+		err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"stormglass_cache_hits_total",
+			"stormglass_cache_misses_total",
+			"stormglass_daily_quota",
+			"stormglass_request_count",
+			"stormglass_requests_total",
+			"stormglass_retries_total",
+		)
+		assert.NoError(t, err)
+
+		count, err := testutil.GatherAndCount(registry, "stormglass_request_duration_seconds")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
@@ -0,0 +1,559 @@
+package stormglasstest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+// stationCount is the number of tide stations served.
+const stationCount = 250
+
+func weatherSources() []string {
+	return []string{"icon", "dwd", "noaa", "meteo", "meto", "fcoo", "fmi", "yr", "smhi", "sg"}
+}
+
+func bioSources() []string {
+	return []string{"meteo", "sg"}
+}
+
+func solarSources() []string {
+	return []string{"sg"}
+}
+
+func datums() []string {
+	return []string{string(stormglass.MSL), string(stormglass.MLLW)}
+}
+
+// valueRange is the range of the values of the params containing name.
+type valueRange struct {
+	name      string
+	low, high float64
+}
+
+// weatherRanges returns the ranges of the weather params, matched in order so
+// more specific names come first.
+func weatherRanges() []valueRange {
+	return []valueRange{
+		{"Direction", 0, 360},
+		{"waterTemperature", 4, 26},
+		{"airTemperature", -5, 28},
+		{"Height", 0, 4},
+		{"Period", 3, 16},
+		{"gust", 2, 25},
+		{"windSpeed", 0, 18},
+		{"currentSpeed", 0, 1.5},
+		{"humidity", 40, 100},
+		{"pressure", 985, 1035},
+		{"cloudCover", 0, 100},
+		{"precipitation", 0, 3},
+		{"visibility", 2, 30},
+		{"iceCover", 0, 0.2},
+		{"snowDepth", 0, 0.3},
+		{"seaLevel", -1.5, 1.5},
+	}
+}
+
+// bioRanges returns the ranges of the bio params, matched in order.
+func bioRanges() []valueRange {
+	return []valueRange{
+		{"chlorophyll", 0, 5},
+		{"iron", 0, 0.002},
+		{"nitrate", 0, 20},
+		{"oxygen", 200, 340},
+		{"phosphate", 0, 1.5},
+		{"phyto", 0, 4},
+		{"ph", 7.8, 8.3},
+		{"salinity", 30, 37},
+		{"silicate", 0, 15},
+	}
+}
+
+const (
+	// tidePeriod is the period of the principal lunar semi-diurnal tide.
+	tidePeriod   = 12.42 * float64(time.Hour)
+	synodicMonth = 29.530588853 * 24 * float64(time.Hour)
+	earthRadius  = 6371.0
+)
+
+// newMoon returns a reference new moon.
+func newMoon() time.Time {
+	return time.Date(2000, time.January, 6, 18, 14, 0, 0, time.UTC)
+}
+
+func (s *Server) weatherPoint(q *query) interface{} {
+	q.coordinates()
+	q.timeRange(24)
+	q.params = q.list("params", jsonNames(stormglass.Hour{}), true)
+	q.sources = q.list("source", weatherSources(), false)
+
+	return map[string]interface{}{
+		"hours": q.hourly(func(param, source string, t time.Time) float64 {
+			r := findRange(weatherRanges(), param)
+			v := r.low + (r.high-r.low)*q.series(param, source, t)
+			if r.high == 360 {
+				v = math.Mod(v, 360)
+			}
+
+			return v
+		}),
+		"meta": s.meta(q),
+	}
+}
+
+func (s *Server) bioPoint(q *query) interface{} {
+	q.coordinates()
+	q.timeRange(24)
+	q.params = q.list("params", jsonNames(stormglass.BioHour{}), true)
+	q.sources = q.list("source", bioSources(), false)
+
+	return map[string]interface{}{
+		"hours": q.hourly(func(param, source string, t time.Time) float64 {
+			r := findRange(bioRanges(), param)
+
+			return r.low + (r.high-r.low)*q.series(param, source, t)
+		}),
+		"meta": s.meta(q),
+	}
+}
+
+func (s *Server) solarPoint(q *query) interface{} {
+	q.coordinates()
+	q.timeRange(24)
+	q.params = q.list("params", jsonNames(stormglass.SolarHour{}), true)
+	q.sources = q.list("source", solarSources(), false)
+
+	return map[string]interface{}{
+		"hours": q.hourly(func(param, source string, t time.Time) float64 {
+			peak := 1000.0
+			if param == "uvIndex" {
+				peak = 11
+			}
+
+			// only the sun above the horizon radiates, scaled by the cloud free sky.
+			return peak * math.Max(0, sunAltitude(q.lat, q.lng, t)) * (0.5 + 0.5*q.series(param, source, t))
+		}),
+		"meta": s.meta(q),
+	}
+}
+
+func (s *Server) tideExtremesPoint(q *query) interface{} {
+	q.coordinates()
+	q.timeRange(24)
+	datum := q.datum()
+	station := s.nearestStation(q.lat, q.lng)
+	tide := newTide(station, datum)
+
+	// extremes are half a period apart, high tides at even multiples from the reference.
+	var data []stormglass.ExtremesPoint
+	half := tidePeriod / 2
+	for k := math.Ceil(float64(q.start.Sub(tide.reference)) / half); ; k++ {
+		t := tide.reference.Add(time.Duration(k * half)).Truncate(time.Minute)
+		if t.After(q.end) {
+			break
+		}
+
+		extreme := stormglass.ExtremesPoint{Time: t, Height: round(tide.height(t)), Type: "low"}
+		if math.Mod(k, 2) == 0 {
+			extreme.Type = "high"
+		}
+
+		data = append(data, extreme)
+	}
+
+	return stormglass.ExtremesPoints{
+		Data: data,
+		Meta: stormglass.ExtremesPointMeta{Meta: s.meta(q), Datum: datum, Station: station},
+	}
+}
+
+func (s *Server) tideSeaLevelPoint(q *query) interface{} {
+	q.coordinates()
+	q.timeRange(24)
+	datum := q.datum()
+	station := s.nearestStation(q.lat, q.lng)
+	tide := newTide(station, datum)
+
+	var data []stormglass.SeaLevelPoint
+	for _, t := range q.hours() {
+		data = append(data, stormglass.SeaLevelPoint{Time: t, SeaLevel: round(tide.height(t))})
+	}
+
+	return stormglass.SeaLevelPoints{
+		Data: data,
+		Meta: stormglass.ExtremesPointMeta{Meta: s.meta(q), Datum: datum, Station: station},
+	}
+}
+
+func (s *Server) tideStations(q *query) interface{} {
+	return stormglass.TideStations{Data: s.stations, Meta: s.meta(q)}
+}
+
+func (s *Server) tideStationsArea(q *query) interface{} {
+	b, ok := parseBox(q.get("box"))
+	if !ok {
+		q.fail("box", "Must be formatted as topRightLat,topRightLng:bottomLeftLat,bottomLeftLng")
+		return nil
+	}
+
+	data := []stormglass.ExtremesPointStation{}
+	for _, station := range s.stations {
+		if b.contains(station.Lat, station.Lng) {
+			data = append(data, station)
+		}
+	}
+
+	return stormglass.TideStations{Data: data, Meta: s.meta(q)}
+}
+
+func (s *Server) astronomyPoint(q *query) interface{} {
+	q.coordinates()
+	q.timeRange(0)
+
+	var data []stormglass.AstronomyDay
+	first := q.start.Truncate(24 * time.Hour)
+	for day := first; day.Equal(first) || day.Before(q.end); day = day.AddDate(0, 0, 1) {
+		data = append(data, astronomyDay(q.lat, q.lng, day))
+	}
+
+	return stormglass.AstronomyPoints{Data: data, Meta: s.meta(q)}
+}
+
+func (s *Server) elevationPoint(q *query) interface{} {
+	q.coordinates()
+
+	lat, lng := radians(q.lat), radians(q.lng)
+	elevation := -4500 + 7000*(0.5+0.3*math.Sin(3*lat)*math.Cos(2*lng)+0.2*(noise("elevation", q.lat, q.lng)-0.5))
+
+	return stormglass.ElevationPoint{
+		Data: stormglass.ElevationPointData{Elevation: math.Round(elevation)},
+		Meta: stormglass.ElevationPointMeta{
+			Meta:     s.meta(q),
+			Distance: round(noise("distance", q.lat, q.lng)),
+			Unit:     "m",
+		},
+	}
+}
+
+// hourly returns the hours of the time range with a value per param and source.
+func (q *query) hourly(value func(param, source string, t time.Time) float64) []map[string]interface{} {
+	hours := []map[string]interface{}{}
+	for _, t := range q.hours() {
+		hour := map[string]interface{}{"time": t.Format(dataTimeFormat)}
+
+		for _, param := range q.params {
+			if param == "time" {
+				continue
+			}
+
+			values := map[string]float64{}
+			for _, source := range q.sources {
+				values[source] = round(value(param, source, t))
+			}
+
+			hour[param] = values
+		}
+
+		hours = append(hours, hour)
+	}
+
+	return hours
+}
+
+// series returns a value between 0 and 1 for the param at the query location,
+// varying smoothly over the day and the week. Sources differ slightly.
+func (q *query) series(param, source string, t time.Time) float64 {
+	base := noise(param, q.lat, q.lng)
+	phase := 2 * math.Pi * noise(q.lat, q.lng, param)
+	hours := float64(t.Unix()) / 3600
+
+	v := 0.2 + 0.6*base +
+		0.1*math.Sin(2*math.Pi*hours/24+phase) +
+		0.1*math.Sin(2*math.Pi*hours/(24*7)+phase) +
+		0.08*(noise(param, source, t.Unix())-0.5)
+
+	return math.Max(0, math.Min(1, v))
+}
+
+func (q *query) datum() stormglass.ExtremesPointsDatumOption {
+	if q.get("datum") == "" {
+		return stormglass.MSL
+	}
+
+	datum := q.list("datum", datums(), true)
+	if len(datum) != 1 {
+		q.fail("datum", "Must be one of %s", strings.Join(datums(), ", "))
+		return stormglass.MSL
+	}
+
+	return stormglass.ExtremesPointsDatumOption(datum[0])
+}
+
+// tide is a single harmonic tide at a station.
+type tide struct {
+	amplitude float64
+	offset    float64
+	// reference is the time of a high tide.
+	reference time.Time
+}
+
+func newTide(station stormglass.ExtremesPointStation, datum stormglass.ExtremesPointsDatumOption) tide {
+	t := tide{
+		amplitude: 0.3 + 2*noise("amplitude", station.Name),
+		reference: newMoon().Add(time.Duration(noise("reference", station.Name) * tidePeriod)),
+	}
+
+	// the mean lower low water datum puts the lowest tides at zero.
+	if datum == stormglass.MLLW {
+		t.offset = t.amplitude
+	}
+
+	return t
+}
+
+func (t tide) height(at time.Time) float64 {
+	return t.offset + t.amplitude*math.Cos(2*math.Pi*float64(at.Sub(t.reference))/tidePeriod)
+}
+
+func newStations(n int) []stormglass.ExtremesPointStation {
+	stations := make([]stormglass.ExtremesPointStation, 0, n)
+	for i := 0; i < n; i++ {
+		stations = append(stations, stormglass.ExtremesPointStation{
+			Lat:    round(-70 + 140*noise("station lat", i)),
+			Lng:    round(-180 + 360*noise("station lng", i)),
+			Name:   fmt.Sprintf("stormglasstest %03d", i),
+			Source: "stormglasstest",
+		})
+	}
+
+	return stations
+}
+
+func (s *Server) nearestStation(lat, lng float64) stormglass.ExtremesPointStation {
+	var nearest stormglass.ExtremesPointStation
+	for i, station := range s.stations {
+		station.Distance = round(distance(lat, lng, station.Lat, station.Lng))
+		if i == 0 || station.Distance < nearest.Distance {
+			nearest = station
+		}
+	}
+
+	return nearest
+}
+
+// box is an area between its top right and bottom left corners, as lat, lng.
+type box struct {
+	topRight, bottomLeft [2]float64
+}
+
+func (b box) contains(lat, lng float64) bool {
+	return lat <= b.topRight[0] && lat >= b.bottomLeft[0] &&
+		lng <= b.topRight[1] && lng >= b.bottomLeft[1]
+}
+
+// parseBox parses a box formatted as topRightLat,topRightLng:bottomLeftLat,bottomLeftLng.
+func parseBox(raw string) (box, bool) {
+	corners := strings.Split(raw, ":")
+	if len(corners) != 2 {
+		return box{}, false
+	}
+
+	var points [2][2]float64
+	for i, corner := range corners {
+		coords := strings.Split(corner, ",")
+		if len(coords) != 2 {
+			return box{}, false
+		}
+
+		for j, coord := range coords {
+			v, err := strconv.ParseFloat(coord, 64)
+			if err != nil {
+				return box{}, false
+			}
+
+			points[i][j] = v
+		}
+	}
+
+	b := box{topRight: points[0], bottomLeft: points[1]}
+
+	return b, b.topRight[0] >= b.bottomLeft[0] && b.topRight[1] >= b.bottomLeft[1]
+}
+
+func astronomyDay(lat, lng float64, day time.Time) stormglass.AstronomyDay {
+	a := stormglass.AstronomyDay{Time: day}
+
+	sun := sunEvents(lat, lng, day, -0.833)
+	a.Sunrise, a.Sunset = sun.rise, sun.set
+
+	civil := sunEvents(lat, lng, day, -6)
+	a.CivilDawn, a.CivilDusk = civil.rise, civil.set
+
+	nautical := sunEvents(lat, lng, day, -12)
+	a.NauticalDawn, a.NauticalDusk = nautical.rise, nautical.set
+
+	astronomical := sunEvents(lat, lng, day, -18)
+	a.AstronomicalDawn, a.AstronomicalDusk = astronomical.rise, astronomical.set
+
+	noon := day.Add(12 * time.Hour)
+	phase := moonPhase(noon)
+	a.MoonFraction = round((1 - math.Cos(2*math.Pi*phase)) / 2)
+	a.MoonPhase.Current = stormglass.MoonPhaseValue{Text: moonPhaseText(phase), Time: noon, Value: round(phase)}
+
+	// the closest of the principal phases, at quarters of the synodic month.
+	closest := math.Round(phase*4) / 4
+	a.MoonPhase.Closest = stormglass.MoonPhaseValue{
+		Text:  moonPhaseText(math.Mod(closest, 1)),
+		Time:  noon.Add(time.Duration((closest - phase) * synodicMonth)).Truncate(time.Minute),
+		Value: math.Mod(closest, 1),
+	}
+
+	// the moon rises and sets later than the sun as it ages.
+	lag := time.Duration(phase * 24.84 * float64(time.Hour))
+	a.Moonrise = wrapDay(day, a.Sunrise, lag)
+	a.Moonset = wrapDay(day, a.Sunset, lag)
+
+	return a
+}
+
+// sunEvent holds the times the sun rises above and sets below an altitude.
+type sunEvent struct {
+	rise, set *time.Time
+}
+
+// sunEvents returns the times the sun passes the altitude in degrees on the
+// day, without times when it does not.
+func sunEvents(lat, lng float64, day time.Time, altitude float64) sunEvent {
+	decl := declination(day)
+	cosH := (math.Sin(radians(altitude)) - math.Sin(radians(lat))*math.Sin(decl)) /
+		(math.Cos(radians(lat)) * math.Cos(decl))
+	if cosH < -1 || cosH > 1 {
+		return sunEvent{}
+	}
+
+	noon := day.Add(time.Duration((12 - lng/15) * float64(time.Hour)))
+	h := time.Duration(math.Acos(cosH) * 12 / math.Pi * float64(time.Hour))
+
+	rise, set := noon.Add(-h).Truncate(time.Minute), noon.Add(h).Truncate(time.Minute)
+
+	return sunEvent{rise: &rise, set: &set}
+}
+
+// sunAltitude returns the sine of the altitude of the sun.
+func sunAltitude(lat, lng float64, t time.Time) float64 {
+	decl := declination(t)
+	hours := float64(t.Hour()) + float64(t.Minute())/60
+	hourAngle := radians((hours + lng/15 - 12) * 15)
+
+	return math.Sin(radians(lat))*math.Sin(decl) + math.Cos(radians(lat))*math.Cos(decl)*math.Cos(hourAngle)
+}
+
+// declination approximates the declination of the sun in radians.
+func declination(t time.Time) float64 {
+	return radians(23.44) * math.Sin(2*math.Pi*float64(284+t.YearDay())/365)
+}
+
+// moonPhase returns the age of the moon as a fraction of the synodic month.
+func moonPhase(t time.Time) float64 {
+	phase := math.Mod(float64(t.Sub(newMoon()))/synodicMonth, 1)
+	if phase < 0 {
+		phase++
+	}
+
+	return phase
+}
+
+func moonPhaseText(phase float64) string {
+	switch {
+	case phase < 0.03 || phase > 0.97:
+		return "New moon"
+	case phase < 0.22:
+		return "Waxing crescent"
+	case phase < 0.28:
+		return "First quarter"
+	case phase < 0.47:
+		return "Waxing gibbous"
+	case phase < 0.53:
+		return "Full moon"
+	case phase < 0.72:
+		return "Waning gibbous"
+	case phase < 0.78:
+		return "Last quarter"
+	default:
+		return "Waning crescent"
+	}
+}
+
+// wrapDay returns t delayed by lag, wrapped into the day.
+func wrapDay(day time.Time, t *time.Time, lag time.Duration) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	offset := (t.Sub(day) + lag) % (24 * time.Hour)
+	if offset < 0 {
+		offset += 24 * time.Hour
+	}
+
+	wrapped := day.Add(offset).Truncate(time.Minute)
+
+	return &wrapped
+}
+
+// distance returns the great circle distance in kilometers.
+func distance(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func findRange(ranges []valueRange, param string) valueRange {
+	for _, r := range ranges {
+		if strings.Contains(param, r.name) {
+			return r
+		}
+	}
+
+	return valueRange{low: 0, high: 1}
+}
+
+// noise returns a deterministic pseudo random value between 0 and 1 for the parts.
+func noise(parts ...interface{}) float64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprint(h, parts...)
+
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// jsonNames returns the JSON names of the fields of a struct.
+func jsonNames(v interface{}) []string {
+	t := reflect.TypeOf(v)
+
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,247 @@
+package stormglasstest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNotRecorded is returned when replaying a request without recording.
+var ErrNotRecorded = errors.New("stormglasstest: request not recorded")
+
+// Mode selects whether a Recorder records or replays responses.
+type Mode int
+
+// Recorder modes.
+const (
+	// ModeReplay serves recorded responses without sending requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests and records their responses, replacing earlier recordings.
+	ModeRecord
+)
+
+// Recorder is an http.RoundTripper recording request and response pairs as JSON
+// files in a directory, to replay them in tests running without network or API
+// key. Requests match their recording by method, path and normalized query;
+// the Authorization header and key query parameter are never written to disk.
+type Recorder struct {
+	dir       string
+	mode      Mode
+	transport http.RoundTripper
+}
+
+// NewRecorder returns a Recorder keeping recordings in dir, which is created
+// when missing in ModeRecord. Requests are recorded using transport, or
+// http.DefaultTransport when nil.
+func NewRecorder(dir string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if mode == ModeRecord {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{dir: dir, mode: mode, transport: transport}, nil
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	// Body holds JSON bodies as is, so recordings are readable and diffable.
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"bodyText,omitempty"`
+}
+
+type recording struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// RoundTrip records or replays the response of the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := recording{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL),
+			Header: req.Header.Clone(),
+		},
+		Response: recordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+		},
+	}
+	rec.Request.Header.Del("Authorization")
+	// the body is reformatted when written, so its recorded length would not match.
+	rec.Response.Header.Del("Content-Length")
+
+	if json.Valid(body) {
+		rec.Response.Body = body
+	} else {
+		rec.Response.BodyText = string(body)
+	}
+
+	if err = r.write(r.path(req), rec); err != nil {
+		return nil, fmt.Errorf("stormglasstest: write recording: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	data, err := os.ReadFile(r.path(req))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, scrubURL(req.URL))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rec recording
+	if err = json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("stormglasstest: read recording: %w", err)
+	}
+
+	body := []byte(rec.Response.BodyText)
+	if len(rec.Response.Body) > 0 {
+		body = rec.Response.Body
+	}
+
+	header := rec.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Response.StatusCode, http.StatusText(rec.Response.StatusCode)),
+		StatusCode:    rec.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// write writes the recording to a temporary file first so replays never see a
+// partial recording.
+func (r *Recorder) write(path string, rec recording) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(r.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// path returns the recording file of the request, named after its path and a
+// hash of the method, path and normalized query.
+func (r *Recorder) path(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.Path + "?" + normalizeQuery(req.URL.Query())))
+	name := strings.ReplaceAll(strings.Trim(req.URL.Path, "/"), "/", "-")
+
+	return filepath.Join(r.dir, name+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// normalizeQuery encodes the query without the API key, with numbers formatted
+// canonically and the params and sources lists sorted.
+func normalizeQuery(values url.Values) string {
+	normalized := url.Values{}
+	for key, vs := range values {
+		if key == "key" {
+			continue
+		}
+
+		for _, v := range vs {
+			switch key {
+			case "params", "source":
+				items := strings.Split(v, ",")
+				sort.Strings(items)
+				v = strings.Join(items, ",")
+			default:
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					v = strconv.FormatFloat(f, 'f', -1, 64)
+				}
+			}
+
+			normalized.Add(key, v)
+		}
+
+		sort.Strings(normalized[key])
+	}
+
+	return normalized.Encode()
+}
+
+func scrubURL(u *url.URL) string {
+	values := u.Query()
+	values.Del("key")
+
+	scrubbed := *u
+	scrubbed.RawQuery = values.Encode()
+
+	return scrubbed.String()
+}
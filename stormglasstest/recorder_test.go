@@ -0,0 +1,163 @@
+package stormglasstest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+func TestRecorder(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		start   = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+		options = stormglass.PointsRequestOptions{
+			CommonRequestOptions: stormglass.CommonRequestOptions{Lat: 58.7984, Lng: 17.8081, Start: &start},
+			Params:               stormglass.WeatherParamsOptions{WaveHeight: true},
+		}
+	)
+
+	t.Run("replays recorded responses", func(t *testing.T) {
+		dir := t.TempDir()
+		s := NewServer()
+
+		recorder, err := NewRecorder(dir, ModeRecord, nil)
+		require.NoError(t, err)
+
+		c := stormglass.NewClient(s.APIKey(),
+			stormglass.WithBaseURL(s.BaseURL()),
+			stormglass.WithHTTPClient(&http.Client{Transport: recorder}),
+		)
+
+		recorded, err := c.GetPoint(ctx, options)
+		require.NoError(t, err)
+
+		_, err = c.GetPoint(ctx, stormglass.PointsRequestOptions{
+			CommonRequestOptions: options.CommonRequestOptions,
+			Params:               stormglass.WeatherParamsOptions{AirTemperature: true},
+		})
+		require.NoError(t, err)
+
+		// replays must not depend on the server nor on the key.
+		s.Close()
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+
+		for _, f := range files {
+			data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), s.APIKey(), "expected the key to be scrubbed")
+		}
+
+		replayer, err := NewRecorder(dir, ModeReplay, nil)
+		require.NoError(t, err)
+
+		c = stormglass.NewClient("another-key",
+			stormglass.WithBaseURL(s.BaseURL()),
+			stormglass.WithHTTPClient(&http.Client{Transport: replayer}),
+		)
+
+		replayed, err := c.GetPoint(ctx, options)
+		require.NoError(t, err)
+		assert.Equal(t, recorded, replayed)
+
+		_, err = c.GetPoint(ctx, stormglass.PointsRequestOptions{
+			CommonRequestOptions: options.CommonRequestOptions,
+			Params:               stormglass.WeatherParamsOptions{SwellHeight: true},
+		})
+		assert.ErrorIs(t, err, ErrNotRecorded)
+	})
+
+	t.Run("replays the length of the recorded body", func(t *testing.T) {
+		dir := t.TempDir()
+		body := `{"data":{"elevation":12},"meta":{"cost":1}}`
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, body)
+		}))
+		defer ts.Close()
+
+		recorder, err := NewRecorder(dir, ModeRecord, nil)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/v2/elevation/point?lat=1&lng=2", nil)
+		require.NoError(t, err)
+
+		res, err := recorder.RoundTrip(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, strconv.Itoa(len(body)), res.Header.Get("Content-Length"))
+
+		replayer, err := NewRecorder(dir, ModeReplay, nil)
+		require.NoError(t, err)
+
+		res, err = replayer.RoundTrip(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		replayed, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, body, string(replayed))
+		assert.Equal(t, strconv.Itoa(len(replayed)), res.Header.Get("Content-Length"))
+		assert.Equal(t, int64(len(replayed)), res.ContentLength)
+	})
+
+	t.Run("replays recorded errors", func(t *testing.T) {
+		dir := t.TempDir()
+		s := NewServer()
+		defer s.Close()
+
+		recorder, err := NewRecorder(dir, ModeRecord, nil)
+		require.NoError(t, err)
+
+		c := stormglass.NewClient("invalid",
+			stormglass.WithBaseURL(s.BaseURL()),
+			stormglass.WithHTTPClient(&http.Client{Transport: recorder}),
+		)
+
+		_, err = c.GetPoint(ctx, options)
+		require.ErrorIs(t, err, stormglass.ErrInvalidKey)
+
+		replayer, err := NewRecorder(dir, ModeReplay, nil)
+		require.NoError(t, err)
+
+		c.HTTPClient = &http.Client{Transport: replayer}
+
+		_, err = c.GetPoint(ctx, options)
+		assert.ErrorIs(t, err, stormglass.ErrInvalidKey)
+		assert.Equal(t, "403: key:API key is invalid", err.Error())
+	})
+
+	t.Run("matches on normalized query", func(t *testing.T) {
+		r, err := NewRecorder(t.TempDir(), ModeReplay, nil)
+		require.NoError(t, err)
+
+		path := func(query string) string {
+			req, err := http.NewRequest(http.MethodGet, "https://api.stormglass.io/v2/weather/point?"+query, nil)
+			require.NoError(t, err)
+
+			return r.path(req)
+		}
+
+		expected := path("lat=58.7984&lng=17.8081&params=waveHeight,windSpeed&source=sg,noaa")
+		assert.Equal(t, expected, path("source=noaa,sg&params=windSpeed,waveHeight&lng=17.808100&lat=58.798400&key=secret"))
+		assert.NotEqual(t, expected, path("lat=58.7984&lng=17.8081&params=waveHeight"))
+		assert.Contains(t, filepath.Base(expected), "v2-weather-point-")
+
+		values, err := url.ParseQuery("box=1.500000,2.000000:0.500000,1.000000")
+		require.NoError(t, err)
+		assert.Equal(t, "box=1.500000%2C2.000000%3A0.500000%2C1.000000", normalizeQuery(values))
+	})
+}
@@ -0,0 +1,402 @@
+// Package stormglasstest provides an in-process fake of the Stormglass API for
+// tests which must not depend on the network or an API key.
+//
+// The fake serves every endpoint implemented by the client with plausible data
+// which is deterministic for the requested coordinates, time range, params and
+// sources. It validates the query parameters like the API does, and simulates
+// invalid API keys, the daily quota and rate limiting.
+package stormglasstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+const (
+	// DefaultAPIKey is the API key accepted by a Server without WithAPIKey.
+	DefaultAPIKey = "stormglasstest-key"
+	// DefaultDailyQuota is the daily quota of a Server without WithDailyQuota.
+	DefaultDailyQuota = 1000
+
+	basePath = "/v2/"
+	// maxHours is the longest time range served, like the ten days of the API.
+	maxHours = 10 * 24
+)
+
+// Option configures a Server.
+type Option func(s *Server)
+
+// WithAPIKey sets the API key accepted by the server.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithDailyQuota sets the number of requests allowed per day.
+func WithDailyQuota(quota int) Option {
+	return func(s *Server) {
+		s.dailyQuota = quota
+	}
+}
+
+// WithNow sets the clock used for the default start of time ranges.
+func WithNow(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+type failure struct {
+	status int
+	times  int
+}
+
+// Server is a fake Stormglass API served over HTTP.
+type Server struct {
+	*httptest.Server
+
+	apiKey     string
+	dailyQuota int
+	now        func() time.Time
+
+	stations []stormglass.ExtremesPointStation
+
+	mu           sync.Mutex
+	requestCount int
+	failures     []failure
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:     DefaultAPIKey,
+		dailyQuota: DefaultDailyQuota,
+		now:        time.Now,
+		stations:   newStations(stationCount),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// BaseURL returns the base URL of the fake API, to use in place of stormglass.BaseURLV2.
+func (s *Server) BaseURL() string {
+	return s.URL + strings.TrimSuffix(basePath, "/")
+}
+
+// APIKey returns the API key accepted by the server.
+func (s *Server) APIKey() string {
+	return s.apiKey
+}
+
+// NewClient returns a client of the server using its API key. The options are
+// applied after the ones pointing the client at the server.
+func (s *Server) NewClient(opts ...stormglass.Option) *stormglass.Client {
+	opts = append([]stormglass.Option{
+		stormglass.WithBaseURL(s.BaseURL()),
+		stormglass.WithHTTPClient(s.Client()),
+	}, opts...)
+
+	return stormglass.NewClient(s.apiKey, opts...)
+}
+
+// FailNext makes the next times requests fail with the status code, e.g.
+// http.StatusTooManyRequests to simulate rate limiting.
+func (s *Server) FailNext(status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{status: status, times: times})
+}
+
+// RequestCount returns the number of requests counted against the daily quota.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requestCount
+}
+
+// SetRequestCount sets the number of requests counted against the daily quota.
+func (s *Server) SetRequestCount(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requestCount = count
+}
+
+type handler func(s *Server, q *query) interface{}
+
+func (s *Server) handlers() map[stormglass.Endpoint]handler {
+	return map[stormglass.Endpoint]handler{
+		stormglass.EndpointWeatherPoint:      (*Server).weatherPoint,
+		stormglass.EndpointBioPoint:          (*Server).bioPoint,
+		stormglass.EndpointSolarPoint:        (*Server).solarPoint,
+		stormglass.EndpointTideExtremesPoint: (*Server).tideExtremesPoint,
+		stormglass.EndpointTideSeaLevelPoint: (*Server).tideSeaLevelPoint,
+		stormglass.EndpointTideStations:      (*Server).tideStations,
+		stormglass.EndpointTideStationsArea:  (*Server).tideStationsArea,
+		stormglass.EndpointAstronomyPoint:    (*Server).astronomyPoint,
+		stormglass.EndpointElevationPoint:    (*Server).elevationPoint,
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrors(w, http.StatusMethodNotAllowed, map[string]string{"method": "Method not allowed"})
+		return
+	}
+
+	h, ok := s.handlers()[stormglass.Endpoint(strings.TrimPrefix(r.URL.Path, basePath))]
+	if !ok || !strings.HasPrefix(r.URL.Path, basePath) {
+		writeErrors(w, http.StatusNotFound, map[string]string{"path": "Not found"})
+		return
+	}
+
+	if r.Header.Get("Authorization") != s.apiKey {
+		writeErrors(w, http.StatusForbidden, map[string]string{"key": "API key is invalid"})
+		return
+	}
+
+	if status, failed := s.nextFailure(); failed {
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+
+		writeErrors(w, status, map[string]string{"error": http.StatusText(status)})
+		return
+	}
+
+	q := newQuery(r.URL.Query(), s.now())
+
+	res := h(s, q)
+	if len(q.errors) > 0 {
+		writeErrors(w, http.StatusUnprocessableEntity, q.errors)
+		return
+	}
+
+	if !s.count() {
+		writeErrors(w, http.StatusPaymentRequired, map[string]string{"key": "Daily quota exceeded"})
+		return
+	}
+
+	s.writeJSON(w, res)
+}
+
+func (s *Server) nextFailure() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.failures) > 0 {
+		f := &s.failures[0]
+		if f.times <= 0 {
+			s.failures = s.failures[1:]
+			continue
+		}
+
+		f.times--
+
+		return f.status, true
+	}
+
+	return 0, false
+}
+
+// count counts a request against the daily quota and reports whether it is allowed.
+func (s *Server) count() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.requestCount >= s.dailyQuota {
+		return false
+	}
+
+	s.requestCount++
+
+	return true
+}
+
+// meta returns the meta data of a response for the query.
+func (s *Server) meta(q *query) stormglass.Meta {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta := stormglass.Meta{
+		Cost:         1,
+		DailyQuota:   s.dailyQuota,
+		RequestCount: s.requestCount + 1,
+		Lat:          q.lat,
+		Lng:          q.lng,
+		Params:       q.params,
+	}
+
+	if !q.start.IsZero() {
+		meta.Start = q.start.Format(metaTimeFormat)
+		meta.End = q.end.Format(metaTimeFormat)
+	}
+
+	return meta
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, status int, errors map[string]string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": errors})
+}
+
+const (
+	metaTimeFormat = "2006-01-02 15:04"
+	dataTimeFormat = "2006-01-02T15:04:05+00:00"
+)
+
+// query parses and validates the query parameters of a request, collecting the
+// errors per parameter.
+type query struct {
+	values  map[string][]string
+	now     time.Time
+	errors  map[string]string
+	lat     float64
+	lng     float64
+	start   time.Time
+	end     time.Time
+	params  []string
+	sources []string
+}
+
+func newQuery(values map[string][]string, now time.Time) *query {
+	return &query{
+		values: values,
+		now:    now,
+		errors: map[string]string{},
+	}
+}
+
+func (q *query) get(key string) string {
+	if v := q.values[key]; len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+func (q *query) fail(key, format string, args ...interface{}) {
+	if _, ok := q.errors[key]; !ok {
+		q.errors[key] = fmt.Sprintf(format, args...)
+	}
+}
+
+// coordinates parses the required lat and lng parameters.
+func (q *query) coordinates() {
+	q.lat = q.float("lat", -90, 90)
+	q.lng = q.float("lng", -180, 180)
+}
+
+func (q *query) float(key string, low, high float64) float64 {
+	raw := q.get(key)
+	if raw == "" {
+		q.fail(key, "Missing parameter")
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v < low || v > high {
+		q.fail(key, "Must be a number between %v and %v", low, high)
+		return 0
+	}
+
+	return v
+}
+
+// timeRange parses the optional start and end parameters, defaulting to the
+// current UTC day.
+func (q *query) timeRange(defaultHours int) {
+	y, m, d := q.now.UTC().Date()
+	q.start = q.time("start", time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	q.end = q.time("end", q.start.Add(time.Duration(defaultHours)*time.Hour))
+
+	switch {
+	case q.end.Before(q.start):
+		q.fail("end", "Must be after start")
+	case q.end.Sub(q.start) > maxHours*time.Hour:
+		q.fail("end", "Time range must not exceed %d hours", maxHours)
+	}
+}
+
+func (q *query) time(key string, def time.Time) time.Time {
+	raw := q.get(key)
+	if raw == "" {
+		return def
+	}
+
+	if unix, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC()
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		q.fail(key, "Must be a UNIX timestamp or an ISO 8601 date")
+		return def
+	}
+
+	return t.UTC()
+}
+
+// list parses a comma separated parameter, validating every value against the
+// allowed ones. Without values, all allowed values are returned unless the
+// parameter is required.
+func (q *query) list(key string, allowed []string, required bool) []string {
+	raw := q.get(key)
+	if raw == "" {
+		if required {
+			q.fail(key, "Missing parameter")
+		}
+
+		return allowed
+	}
+
+	valid := map[string]bool{}
+	for _, a := range allowed {
+		valid[a] = true
+	}
+
+	var list []string
+	for _, v := range strings.Split(raw, ",") {
+		if !valid[v] {
+			q.fail(key, "Invalid value %q, must be one of %s", v, strings.Join(allowed, ", "))
+			continue
+		}
+
+		list = append(list, v)
+	}
+
+	return list
+}
+
+// hours returns the hourly times of the time range.
+func (q *query) hours() []time.Time {
+	var hours []time.Time
+	for t := q.start.Truncate(time.Hour); !t.After(q.end); t = t.Add(time.Hour) {
+		hours = append(hours, t)
+	}
+
+	return hours
+}
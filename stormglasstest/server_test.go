@@ -0,0 +1,225 @@
+package stormglasstest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stormglass "github.com/yawlhead91/stormglassgo"
+)
+
+func TestServer(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		start  = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+		end    = start.Add(6 * time.Hour)
+		common = stormglass.CommonRequestOptions{Lat: 58.7984, Lng: 17.8081, Start: &start, End: &end}
+	)
+
+	t.Run("serves deterministic weather", func(t *testing.T) {
+		s := NewServer()
+		defer s.Close()
+
+		c := s.NewClient()
+		options := stormglass.PointsRequestOptions{
+			CommonRequestOptions: common,
+			Params:               stormglass.WeatherParamsOptions{WaveHeight: true, WindDirection: true, WindSpeed100M: true},
+			Source:               stormglass.WeatherSourcesOptions{NOAA: true, StormGlass: true},
+		}
+
+		res, err := c.GetPoint(ctx, options)
+		require.NoError(t, err)
+		require.Len(t, res.Hours, 7)
+
+		hour := res.Hours[0]
+		assert.Equal(t, start, hour.Time.UTC())
+		require.NotNil(t, hour.WaveHeight)
+		require.NotNil(t, hour.WaveHeight.NOAA)
+		require.NotNil(t, hour.WaveHeight.StormGlass)
+		assert.Nil(t, hour.WaveHeight.ICON)
+		assert.InDelta(t, 2, *hour.WaveHeight.NOAA, 2)
+		require.NotNil(t, hour.WindSpeed100M)
+		require.NotNil(t, hour.WindDirection)
+		assert.InDelta(t, 180, *hour.WindDirection.StormGlass, 180)
+		assert.Nil(t, hour.AirTemperature)
+
+		assert.Equal(t, 1, res.Meta.Cost)
+		assert.Equal(t, DefaultDailyQuota, res.Meta.DailyQuota)
+		assert.Equal(t, 1, res.Meta.RequestCount)
+		assert.Equal(t, "2023-06-01 00:00", res.Meta.Start)
+
+		again, err := c.GetPoint(ctx, options)
+		require.NoError(t, err)
+		assert.Equal(t, res.Hours, again.Hours)
+		assert.Equal(t, 2, s.RequestCount())
+	})
+
+	t.Run("serves bio and solar data", func(t *testing.T) {
+		s := NewServer()
+		defer s.Close()
+
+		c := s.NewClient()
+
+		bio, err := c.GetBioPoint(ctx, stormglass.BioPointRequestOptions{
+			CommonRequestOptions: common,
+			Params:               stormglass.BioParamsOptions{Ph: true, Salinity: true},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, bio.Hours)
+		require.NotNil(t, bio.Hours[0].Ph.MeteoFrance)
+		assert.InDelta(t, 8.05, *bio.Hours[0].Ph.StormGlass, 0.25)
+		assert.Nil(t, bio.Hours[0].Iron)
+
+		solar, err := c.GetSolarPoint(ctx, stormglass.SolarPointRequestOptions{
+			CommonRequestOptions: common,
+			Params:               stormglass.SolarParamsOptions{UvIndex: true},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, solar.Hours)
+		assert.Equal(t, 0.0, *solar.Hours[0].UvIndex.StormGlass, "expected no uv index at night")
+		assert.Greater(t, *solar.Hours[6].UvIndex.StormGlass, 0.0, "expected a uv index in the morning")
+	})
+
+	t.Run("serves tides", func(t *testing.T) {
+		s := NewServer()
+		defer s.Close()
+
+		c := s.NewClient()
+		day := start.Add(24 * time.Hour)
+		options := stormglass.CommonRequestOptions{Lat: common.Lat, Lng: common.Lng, Start: &start, End: &day}
+
+		extremes, err := c.GetExtremesPoint(ctx, stormglass.ExtremesPointsRequestOptions{CommonRequestOptions: options})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(extremes.Data), 3)
+		assert.Equal(t, stormglass.MSL, extremes.Meta.Datum)
+		assert.NotEmpty(t, extremes.Meta.Station.Name)
+
+		for i, e := range extremes.Data[1:] {
+			assert.NotEqual(t, extremes.Data[i].Type, e.Type, "expected alternating extremes")
+		}
+
+		seaLevel, err := c.GetSeaLevelPoint(ctx, stormglass.SeaLevelPointRequestOptions{
+			CommonRequestOptions: options,
+			Datum:                stormglass.MLLW,
+		})
+		require.NoError(t, err)
+		assert.Len(t, seaLevel.Data, 25)
+		assert.Equal(t, extremes.Meta.Station, seaLevel.Meta.Station)
+
+		for _, p := range seaLevel.Data {
+			assert.GreaterOrEqual(t, p.SeaLevel, 0.0, "expected no sea level below the lowest low water")
+		}
+
+		all, err := c.ListTideStations(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all.Data, 250)
+
+		area, err := c.ListTideStationsInArea(ctx, stormglass.TideStationsAreaRequestOptions{
+			TopRightLat: 90, TopRightLng: 180, BottomLeftLat: 0, BottomLeftLng: 0,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, area.Data)
+		assert.Less(t, len(area.Data), len(all.Data))
+
+		for _, station := range area.Data {
+			assert.GreaterOrEqual(t, station.Lat, 0.0)
+			assert.GreaterOrEqual(t, station.Lng, 0.0)
+		}
+	})
+
+	t.Run("serves astronomy and elevation", func(t *testing.T) {
+		s := NewServer()
+		defer s.Close()
+
+		c := s.NewClient()
+		week := start.Add(7 * 24 * time.Hour)
+
+		astronomy, err := c.GetAstronomyPoint(ctx, stormglass.AstronomyPointRequestOptions{
+			CommonRequestOptions: stormglass.CommonRequestOptions{Lat: common.Lat, Lng: common.Lng, Start: &start, End: &week},
+		})
+		require.NoError(t, err)
+		require.Len(t, astronomy.Data, 7)
+
+		day := astronomy.Data[0]
+		require.NotNil(t, day.Sunrise)
+		require.NotNil(t, day.Sunset)
+		assert.True(t, day.Sunrise.Before(*day.Sunset))
+		assert.Nil(t, day.AstronomicalDawn, "expected no astronomical night at midsummer in Sweden")
+		assert.NotEmpty(t, day.MoonPhase.Current.Text)
+
+		polar, err := c.GetAstronomyPoint(ctx, stormglass.AstronomyPointRequestOptions{
+			CommonRequestOptions: stormglass.CommonRequestOptions{Lat: 78, Lng: 15, Start: &start},
+		})
+		require.NoError(t, err)
+		require.Len(t, polar.Data, 1)
+		assert.Nil(t, polar.Data[0].Sunrise, "expected polar day")
+
+		elevation, err := c.GetElevationPoint(ctx, stormglass.ElevationPointRequestOptions{Lat: common.Lat, Lng: common.Lng})
+		require.NoError(t, err)
+		assert.Equal(t, "m", elevation.Meta.Unit)
+		assert.InDelta(t, -1000, elevation.Data.Elevation, 5500)
+	})
+
+	t.Run("validates query params", func(t *testing.T) {
+		s := NewServer()
+		defer s.Close()
+
+		c := s.NewClient()
+
+		_, err := c.GetPoint(ctx, stormglass.PointsRequestOptions{
+			CommonRequestOptions: stormglass.CommonRequestOptions{Lat: 91, Lng: 17, Start: &end, End: &start},
+		})
+		require.ErrorIs(t, err, stormglass.ErrInvalidParameters)
+
+		var apiErr *stormglass.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Contains(t, apiErr.FieldErrors(), "lat")
+		assert.Contains(t, apiErr.FieldErrors(), "end")
+		assert.Contains(t, apiErr.FieldErrors(), "params")
+		assert.Zero(t, s.RequestCount(), "expected invalid requests not to count against the quota")
+
+		_, err = c.ListTideStationsInArea(ctx, stormglass.TideStationsAreaRequestOptions{BottomLeftLat: 10})
+		require.ErrorAs(t, err, &apiErr)
+		assert.Contains(t, apiErr.FieldErrors(), "box")
+
+		req, err := http.NewRequest(http.MethodGet, s.BaseURL()+"/weather/point?lat=1&lng=1&params=windSpeed100M", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", s.APIKey())
+
+		res, err := s.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode, "expected param names to match exactly")
+	})
+
+	t.Run("simulates errors", func(t *testing.T) {
+		s := NewServer(WithDailyQuota(1))
+		defer s.Close()
+
+		options := stormglass.ElevationPointRequestOptions{Lat: common.Lat, Lng: common.Lng}
+
+		_, err := stormglass.NewClient("invalid", stormglass.WithBaseURL(s.BaseURL())).GetElevationPoint(ctx, options)
+		assert.ErrorIs(t, err, stormglass.ErrInvalidKey)
+
+		c := s.NewClient()
+
+		s.FailNext(http.StatusTooManyRequests, 1)
+		_, err = c.GetElevationPoint(ctx, options)
+		assert.ErrorIs(t, err, stormglass.ErrRateLimited)
+
+		s.FailNext(http.StatusServiceUnavailable, 1)
+		retrying := s.NewClient(stormglass.WithRetryPolicy(&stormglass.RetryPolicy{MaxAttempts: 2}))
+		_, err = retrying.GetElevationPoint(ctx, options)
+		assert.NoError(t, err, "expected the retry to succeed")
+
+		_, err = c.GetElevationPoint(ctx, options)
+		assert.ErrorIs(t, err, stormglass.ErrQuotaExceeded)
+
+		s.SetRequestCount(0)
+		_, err = c.GetElevationPoint(ctx, options)
+		assert.NoError(t, err)
+	})
+}
@@ -3,7 +3,6 @@ package stormglass
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"time"
 )
@@ -12,7 +11,7 @@ import (
 type ExtremesPointStation struct {
 	Distance float64 `json:"distance,omitempty"`
 	Lat      float64 `json:"lat,omitempty"`
-	Lng      float64 `json:"lan,omitempty"`
+	Lng      float64 `json:"lng,omitempty"`
 	Name     string  `json:"name,omitempty"`
 	Source   string  `json:"source,omitempty"`
 }
@@ -54,17 +53,52 @@ const (
 
 // GetExtremesPoint send an extreme point request: https://docs.stormglass.io/#/tide?id=extremes-point-request
 func (c *Client) GetExtremesPoint(ctx context.Context, options ExtremesPointsRequestOptions) (*ExtremesPoints, error) {
-	path, err := url.JoinPath(c.BaseURL, "tide", "extremes", "point")
-	if err != nil {
-		return nil, err
+	values := url.Values{}
+	values.Add("lat", fmt.Sprintf("%f", options.Lat))
+	values.Add("lng", fmt.Sprintf("%f", options.Lng))
+
+	if options.Start != nil {
+		values.Add("start", fmt.Sprintf("%d", options.Start.Unix()))
 	}
 
-	u, err := url.Parse(path)
-	if err != nil {
+	if options.End != nil {
+		values.Add("end", fmt.Sprintf("%d", options.End.Unix()))
+	}
+
+	if options.Datum != "" {
+		values.Add("datum", string(options.Datum))
+	}
+
+	res := ExtremesPoints{}
+
+	if err := c.get(ctx, EndpointTideExtremesPoint, options, values, &res); err != nil {
 		return nil, err
 	}
 
-	values := u.Query()
+	return &res, nil
+}
+
+// SeaLevelPoint represents an hourly sea level sample.
+type SeaLevelPoint struct {
+	SeaLevel float64   `json:"sg,omitempty"`
+	Time     time.Time `json:"time,omitempty"`
+}
+
+// SeaLevelPoints represents the sea level point request response.
+type SeaLevelPoints struct {
+	Data []SeaLevelPoint   `json:"data,omitempty"`
+	Meta ExtremesPointMeta `json:"meta,omitempty"`
+}
+
+// SeaLevelPointRequestOptions represents the options for the sea level point request.
+type SeaLevelPointRequestOptions struct {
+	CommonRequestOptions
+	Datum ExtremesPointsDatumOption `json:"datum,omitempty"`
+}
+
+// GetSeaLevelPoint sends a sea level point request: https://docs.stormglass.io/#/tide?id=sea-level-point-request
+func (c *Client) GetSeaLevelPoint(ctx context.Context, options SeaLevelPointRequestOptions) (*SeaLevelPoints, error) {
+	values := url.Values{}
 	values.Add("lat", fmt.Sprintf("%f", options.Lat))
 	values.Add("lng", fmt.Sprintf("%f", options.Lng))
 
@@ -80,17 +114,57 @@ func (c *Client) GetExtremesPoint(ctx context.Context, options ExtremesPointsReq
 		values.Add("datum", string(options.Datum))
 	}
 
-	u.RawQuery = values.Encode()
+	res := SeaLevelPoints{}
 
-	req, err := http.NewRequest("GET", u.String(), http.NoBody)
-	if err != nil {
+	if err := c.get(ctx, EndpointTideSeaLevelPoint, options, values, &res); err != nil {
 		return nil, err
 	}
 
-	req = req.WithContext(ctx)
-	res := ExtremesPoints{}
+	return &res, nil
+}
+
+// TideStations represents the tide stations request response.
+type TideStations struct {
+	Data []ExtremesPointStation `json:"data,omitempty"`
+	Meta Meta                   `json:"meta,omitempty"`
+}
+
+// TideStationsAreaRequestOptions represents the bounding box for the tide stations area request.
+type TideStationsAreaRequestOptions struct {
+	TopRightLat   float64 `json:"topRightLat,omitempty"`
+	TopRightLng   float64 `json:"topRightLng,omitempty"`
+	BottomLeftLat float64 `json:"bottomLeftLat,omitempty"`
+	BottomLeftLng float64 `json:"bottomLeftLng,omitempty"`
+}
+
+// ListTideStations sends a tide stations request: https://docs.stormglass.io/#/tide?id=sea-level-stations
+func (c *Client) ListTideStations(ctx context.Context) (*TideStations, error) {
+	res := TideStations{}
+
+	if err := c.get(ctx, EndpointTideStations, nil, url.Values{}, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
 
-	if err = c.sendRequest(req, &res); err != nil {
+// ListTideStationsInArea sends a tide stations area request:
+// https://docs.stormglass.io/#/tide?id=sea-level-stations-area
+func (c *Client) ListTideStationsInArea(
+	ctx context.Context, options TideStationsAreaRequestOptions,
+) (*TideStations, error) {
+	values := url.Values{}
+	values.Add("box", fmt.Sprintf(
+		"%f,%f:%f,%f",
+		options.TopRightLat,
+		options.TopRightLng,
+		options.BottomLeftLat,
+		options.BottomLeftLng,
+	))
+
+	res := TideStations{}
+
+	if err := c.get(ctx, EndpointTideStationsArea, options, values, &res); err != nil {
 		return nil, err
 	}
 
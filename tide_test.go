@@ -134,3 +134,213 @@ func TestClient_GetExtremesPoint(t *testing.T) {
 		assertion.NotNil(res, "expecting non-nil response")
 	})
 }
+
+func TestClient_GetSeaLevelPoint(t *testing.T) {
+	var (
+		start        = now.BeginningOfDay()
+		end          = now.EndOfDay()
+		testKey      = "testkey123"
+		endpointPath = "/tide/sea-level/point"
+		lat          = 58.7984
+		lng          = 17.8081
+	)
+
+	t.Run("test full url composition", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Add("lat", fmt.Sprintf("%f", lat))
+			expectedValues.Add("lng", fmt.Sprintf("%f", lng))
+			expectedValues.Add("start", fmt.Sprintf("%d", start.Unix()))
+			expectedValues.Add("end", fmt.Sprintf("%d", end.Unix()))
+			expectedValues.Add("datum", "MSL")
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			_, _ = fmt.Fprintln(w, "{}")
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetSeaLevelPoint(ctx, SeaLevelPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat:   lat,
+				Lng:   lng,
+				Start: &start,
+				End:   &end,
+			},
+			Datum: MSL,
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+	})
+
+	t.Run("test full with no datum and dates", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Add("lat", fmt.Sprintf("%f", lat))
+			expectedValues.Add("lng", fmt.Sprintf("%f", lng))
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			_, _ = fmt.Fprintln(w, "{}")
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetSeaLevelPoint(ctx, SeaLevelPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat: lat,
+				Lng: lng,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+	})
+
+	t.Run("test response decoding", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := `{
+				"data": [
+					{"sg": 0.42, "time": "2022-06-01T00:00:00+00:00"},
+					{"sg": -0.13, "time": "2022-06-01T01:00:00+00:00"}
+				],
+				"meta": {
+					"cost": 1,
+					"datum": "MSL",
+					"station": {"distance": 12, "lat": 58.7, "lng": 17.9, "name": "landsort", "source": "sehavniva"}
+				}
+			}`
+			if _, err := w.Write([]byte(want)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.GetSeaLevelPoint(ctx, SeaLevelPointRequestOptions{
+			CommonRequestOptions: CommonRequestOptions{
+				Lat: lat,
+				Lng: lng,
+			},
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+		assertion.Len(res.Data, 2)
+		assertion.Equal(0.42, res.Data[0].SeaLevel)
+		assertion.Equal(-0.13, res.Data[1].SeaLevel)
+		assertion.Equal(MSL, res.Meta.Datum)
+		assertion.Equal("landsort", res.Meta.Station.Name)
+		assertion.Equal(1, res.Meta.Cost)
+	})
+}
+
+func TestClient_ListTideStations(t *testing.T) {
+	var (
+		testKey      = "testkey123"
+		endpointPath = "/tide/sea-level/stations"
+	)
+
+	t.Run("test url composition and decoding", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+			assertion.Equal("", r.URL.RawQuery)
+
+			want := `{
+				"data": [
+					{"lat": 58.7, "lng": 17.9, "name": "landsort", "source": "sehavniva"},
+					{"lat": 59.3, "lng": 18.1, "name": "stockholm", "source": "sehavniva"}
+				],
+				"meta": {"cost": 1}
+			}`
+			if _, err := w.Write([]byte(want)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.ListTideStations(ctx)
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+		assertion.Len(res.Data, 2)
+		assertion.Equal("landsort", res.Data[0].Name)
+		assertion.Equal(58.7, res.Data[0].Lat)
+		assertion.Equal(17.9, res.Data[0].Lng)
+		assertion.Equal(1, res.Meta.Cost)
+	})
+}
+
+func TestClient_ListTideStationsInArea(t *testing.T) {
+	var (
+		testKey      = "testkey123"
+		endpointPath = "/tide/sea-level/stations/area"
+	)
+
+	t.Run("test full url composition", func(t *testing.T) {
+		assertion := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assertion.NotNil(r.URL)
+			assertion.Equal(endpointPath, r.URL.Path)
+
+			expectedValues := url.Values{}
+			expectedValues.Add("box", "60.100000,19.200000:58.500000,16.300000")
+			assertion.Equal(
+				expectedValues.Encode(),
+				r.URL.RawQuery,
+			)
+
+			_, _ = fmt.Fprintln(w, "{}")
+		}))
+		defer ts.Close()
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+
+		ctx := context.Background()
+		res, err := c.ListTideStationsInArea(ctx, TideStationsAreaRequestOptions{
+			TopRightLat:   60.1,
+			TopRightLng:   19.2,
+			BottomLeftLat: 58.5,
+			BottomLeftLng: 16.3,
+		})
+
+		assertion.NoError(err, "expecting nil err")
+		assertion.NotNil(res, "expecting non-nil response")
+	})
+}
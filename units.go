@@ -0,0 +1,394 @@
+package stormglass
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Dimension is the physical dimension of a unit.
+type Dimension string
+
+// Dimensions of the weather variables.
+const (
+	DimensionSpeed         Dimension = "speed"
+	DimensionLength        Dimension = "length"
+	DimensionTemperature   Dimension = "temperature"
+	DimensionPressure      Dimension = "pressure"
+	DimensionDirection     Dimension = "direction"
+	DimensionPrecipitation Dimension = "precipitation"
+	DimensionTime          Dimension = "time"
+	DimensionRatio         Dimension = "ratio"
+)
+
+// Unit is a unit of measurement, named by its symbol. The empty unit is unknown.
+type Unit string
+
+// Units of the weather variables. The API returns the first unit of each dimension.
+const (
+	UnitMetersPerSecond   Unit = "m/s"
+	UnitKnots             Unit = "kn"
+	UnitKilometersPerHour Unit = "km/h"
+	UnitMilesPerHour      Unit = "mph"
+
+	UnitMeters        Unit = "m"
+	UnitKilometers    Unit = "km"
+	UnitFeet          Unit = "ft"
+	UnitInches        Unit = "in"
+	UnitMiles         Unit = "mi"
+	UnitNauticalMiles Unit = "nmi"
+
+	UnitCelsius    Unit = "°C"
+	UnitFahrenheit Unit = "°F"
+
+	UnitHectopascals         Unit = "hPa"
+	UnitMillibars            Unit = "mbar"
+	UnitInchesOfMercury      Unit = "inHg"
+	UnitMillimetersOfMercury Unit = "mmHg"
+
+	UnitDegrees Unit = "°"
+	UnitRadians Unit = "rad"
+
+	UnitMillimetersPerHour Unit = "mm/h"
+	UnitInchesPerHour      Unit = "in/h"
+
+	UnitSeconds Unit = "s"
+	UnitPercent Unit = "%"
+	UnitRatio   Unit = "1"
+)
+
+// unitDefinition converts a unit from the base unit of its dimension, the
+// first one of the dimension, as base = value*scale + offset.
+type unitDefinition struct {
+	dimension Dimension
+	scale     float64
+	offset    float64
+}
+
+// definition returns the definition of the unit, false when unknown.
+func (u Unit) definition() (unitDefinition, bool) {
+	switch u {
+	case UnitMetersPerSecond:
+		return unitDefinition{DimensionSpeed, 1, 0}, true
+	case UnitKnots:
+		return unitDefinition{DimensionSpeed, 1852.0 / 3600, 0}, true
+	case UnitKilometersPerHour:
+		return unitDefinition{DimensionSpeed, 1 / 3.6, 0}, true
+	case UnitMilesPerHour:
+		return unitDefinition{DimensionSpeed, 0.44704, 0}, true
+	case UnitMeters:
+		return unitDefinition{DimensionLength, 1, 0}, true
+	case UnitKilometers:
+		return unitDefinition{DimensionLength, 1000, 0}, true
+	case UnitFeet:
+		return unitDefinition{DimensionLength, 0.3048, 0}, true
+	case UnitInches:
+		return unitDefinition{DimensionLength, 0.0254, 0}, true
+	case UnitMiles:
+		return unitDefinition{DimensionLength, 1609.344, 0}, true
+	case UnitNauticalMiles:
+		return unitDefinition{DimensionLength, 1852, 0}, true
+	case UnitCelsius:
+		return unitDefinition{DimensionTemperature, 1, 0}, true
+	case UnitFahrenheit:
+		return unitDefinition{DimensionTemperature, 5.0 / 9, -32 * 5.0 / 9}, true
+	case UnitHectopascals:
+		return unitDefinition{DimensionPressure, 1, 0}, true
+	case UnitMillibars:
+		return unitDefinition{DimensionPressure, 1, 0}, true
+	case UnitInchesOfMercury:
+		return unitDefinition{DimensionPressure, 33.8639, 0}, true
+	case UnitMillimetersOfMercury:
+		return unitDefinition{DimensionPressure, 1.33322, 0}, true
+	case UnitDegrees:
+		return unitDefinition{DimensionDirection, 1, 0}, true
+	case UnitRadians:
+		return unitDefinition{DimensionDirection, 180 / math.Pi, 0}, true
+	case UnitMillimetersPerHour:
+		return unitDefinition{DimensionPrecipitation, 1, 0}, true
+	case UnitInchesPerHour:
+		return unitDefinition{DimensionPrecipitation, 25.4, 0}, true
+	case UnitSeconds:
+		return unitDefinition{DimensionTime, 1, 0}, true
+	case UnitPercent:
+		return unitDefinition{DimensionRatio, 0.01, 0}, true
+	case UnitRatio:
+		return unitDefinition{DimensionRatio, 1, 0}, true
+	default:
+		return unitDefinition{}, false
+	}
+}
+
+// Dimension returns the dimension of the unit, empty when unknown.
+func (u Unit) Dimension() Dimension {
+	d, _ := u.definition()
+
+	return d.dimension
+}
+
+// Quantity is a value in a unit.
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// String formats the quantity, e.g. "12.5 kn".
+func (q Quantity) String() string {
+	if q.Unit == UnitRatio {
+		return fmt.Sprintf("%g", q.Value)
+	}
+
+	if q.Unit == UnitPercent || strings.HasPrefix(string(q.Unit), "°") {
+		return fmt.Sprintf("%g%s", q.Value, q.Unit)
+	}
+
+	return fmt.Sprintf("%g %s", q.Value, q.Unit)
+}
+
+// In returns the quantity converted to the unit, which must have the same dimension.
+func (q Quantity) In(unit Unit) (Quantity, error) {
+	from, ok := q.Unit.definition()
+	if !ok {
+		return Quantity{}, fmt.Errorf("unknown unit %q", q.Unit)
+	}
+
+	to, ok := unit.definition()
+	if !ok {
+		return Quantity{}, fmt.Errorf("unknown unit %q", unit)
+	}
+
+	if from.dimension != to.dimension {
+		return Quantity{}, fmt.Errorf("cannot convert %s %q to %s %q", from.dimension, q.Unit, to.dimension, unit)
+	}
+
+	base := q.Value*from.scale + from.offset
+
+	return Quantity{Value: (base - to.offset) / to.scale, Unit: unit}, nil
+}
+
+// variableUnit is the unit of the params containing name.
+type variableUnit struct {
+	name string
+	unit Unit
+}
+
+// variableUnits returns the units of the params, matched in order so more
+// specific names come first.
+func variableUnits() []variableUnit {
+	return []variableUnit{
+		{"Direction", UnitDegrees},
+		{"Temperature", UnitCelsius},
+		{"Height", UnitMeters},
+		{"Period", UnitSeconds},
+		{"Speed", UnitMetersPerSecond},
+		{"gust", UnitMetersPerSecond},
+		{"seaLevel", UnitMeters},
+		{"snowDepth", UnitMeters},
+		{"visibility", UnitKilometers},
+		{"pressure", UnitHectopascals},
+		{"precipitation", UnitMillimetersPerHour},
+		{"humidity", UnitPercent},
+		{"cloudCover", UnitPercent},
+		{"iceCover", UnitRatio},
+	}
+}
+
+// VariableUnit returns the unit of the API values of the variable named like
+// its param, e.g. "windSpeed", false when unknown.
+func VariableUnit(name string) (Unit, bool) {
+	for _, v := range variableUnits() {
+		if strings.Contains(name, v.name) {
+			return v.unit, true
+		}
+	}
+
+	return "", false
+}
+
+// Quantity returns the value of the source for the variable named like its
+// param, in the unit of the API, false when missing.
+func (h *Hour) Quantity(name string, source Source) (Quantity, bool) {
+	unit, ok := VariableUnit(name)
+	if !ok {
+		return Quantity{}, false
+	}
+
+	value, ok := h.Variable(name).Get(source)
+	if !ok {
+		return Quantity{}, false
+	}
+
+	return Quantity{Value: value, Unit: unit}, true
+}
+
+// UnitProfile selects the unit of every dimension. Distance is the unit of
+// long lengths, e.g. visibility, while Length is the one of heights and depths.
+// Units left empty are not converted.
+type UnitProfile struct {
+	Speed         Unit
+	Length        Unit
+	Distance      Unit
+	Temperature   Unit
+	Pressure      Unit
+	Direction     Unit
+	Precipitation Unit
+}
+
+// MetricUnits returns the profile of the units of the API.
+func MetricUnits() UnitProfile {
+	return UnitProfile{
+		Speed:         UnitMetersPerSecond,
+		Length:        UnitMeters,
+		Distance:      UnitKilometers,
+		Temperature:   UnitCelsius,
+		Pressure:      UnitHectopascals,
+		Direction:     UnitDegrees,
+		Precipitation: UnitMillimetersPerHour,
+	}
+}
+
+// ImperialUnits returns the profile of the United States customary units.
+func ImperialUnits() UnitProfile {
+	return UnitProfile{
+		Speed:         UnitMilesPerHour,
+		Length:        UnitFeet,
+		Distance:      UnitMiles,
+		Temperature:   UnitFahrenheit,
+		Pressure:      UnitInchesOfMercury,
+		Direction:     UnitDegrees,
+		Precipitation: UnitInchesPerHour,
+	}
+}
+
+// NauticalUnits returns the profile of the metric units with speeds in knots
+// and distances in nautical miles.
+func NauticalUnits() UnitProfile {
+	return UnitProfile{
+		Speed:         UnitKnots,
+		Length:        UnitMeters,
+		Distance:      UnitNauticalMiles,
+		Temperature:   UnitCelsius,
+		Pressure:      UnitHectopascals,
+		Direction:     UnitDegrees,
+		Precipitation: UnitMillimetersPerHour,
+	}
+}
+
+// unit returns the unit of the profile for a variable in the API unit, the API
+// unit when the profile leaves it empty.
+func (p UnitProfile) unit(api Unit) Unit {
+	var unit Unit
+
+	switch api.Dimension() {
+	case DimensionSpeed:
+		unit = p.Speed
+	case DimensionLength:
+		unit = p.Length
+		if api == UnitKilometers {
+			unit = p.Distance
+		}
+	case DimensionTemperature:
+		unit = p.Temperature
+	case DimensionPressure:
+		unit = p.Pressure
+	case DimensionDirection:
+		unit = p.Direction
+	case DimensionPrecipitation:
+		unit = p.Precipitation
+	case DimensionTime, DimensionRatio:
+		// not part of a profile, always in the API unit.
+	}
+
+	if unit == "" {
+		return api
+	}
+
+	return unit
+}
+
+// ConvertedHour holds the quantities of an hour in the units of a profile, by
+// param name and source.
+type ConvertedHour struct {
+	Time   time.Time                      `json:"time"`
+	Values map[string]map[Source]Quantity `json:"values"`
+}
+
+// Quantity returns the quantity of the source for the variable named like its
+// param, false when missing.
+func (h ConvertedHour) Quantity(name string, source Source) (Quantity, bool) {
+	q, ok := h.Values[name][source]
+
+	return q, ok
+}
+
+// ConvertedPoints are weather points in the units of a profile. Unlike Points,
+// their values are quantities, so they are never read in the wrong unit.
+type ConvertedPoints struct {
+	Hours []ConvertedHour `json:"hours"`
+	Meta  Meta            `json:"meta"`
+	// Units holds the unit of every variable by param name.
+	Units map[string]Unit `json:"units"`
+}
+
+// Convert returns the points with the values of every variable in the units of
+// the profile.
+func (p UnitProfile) Convert(points *Points) (*ConvertedPoints, error) {
+	converted := &ConvertedPoints{Units: map[string]Unit{}}
+	if points == nil {
+		return converted, nil
+	}
+
+	converted.Meta = points.Meta
+	converted.Hours = make([]ConvertedHour, len(points.Hours))
+	fields := hourFields()
+
+	for i := range points.Hours {
+		hour := ConvertedHour{Values: map[string]map[Source]Quantity{}}
+		if t := points.Hours[i].Time; t != nil {
+			hour.Time = *t
+		}
+
+		for _, f := range fields {
+			values := points.Hours[i].field(f)
+			if values == nil {
+				continue
+			}
+
+			from, ok := VariableUnit(f.name)
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown unit", f.name)
+			}
+
+			to := p.unit(from)
+
+			quantities, err := values.quantities(from, to)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+
+			hour.Values[f.name] = quantities
+			converted.Units[f.name] = to
+		}
+
+		converted.Hours[i] = hour
+	}
+
+	return converted, nil
+}
+
+// quantities returns the values of the present sources converted between units.
+func (v *WeatherSourceValues) quantities(from, to Unit) (map[Source]Quantity, error) {
+	quantities := map[Source]Quantity{}
+
+	for _, value := range v.Values() {
+		q, err := Quantity{Value: value.Value, Unit: from}.In(to)
+		if err != nil {
+			return nil, err
+		}
+
+		quantities[value.Source] = q
+	}
+
+	return quantities, nil
+}
@@ -0,0 +1,164 @@
+package stormglass
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantity(t *testing.T) {
+	t.Run("converts between units", func(t *testing.T) {
+		tests := []struct {
+			from Quantity
+			to   Unit
+			want float64
+		}{
+			{Quantity{10, UnitMetersPerSecond}, UnitKnots, 19.4384},
+			{Quantity{36, UnitKilometersPerHour}, UnitMetersPerSecond, 10},
+			{Quantity{2, UnitMeters}, UnitFeet, 6.5617},
+			{Quantity{20, UnitCelsius}, UnitFahrenheit, 68},
+			{Quantity{32, UnitFahrenheit}, UnitCelsius, 0},
+			{Quantity{1013.25, UnitHectopascals}, UnitInchesOfMercury, 29.9213},
+			{Quantity{180, UnitDegrees}, UnitRadians, 3.1416},
+			{Quantity{1, UnitInchesPerHour}, UnitMillimetersPerHour, 25.4},
+			{Quantity{85, UnitPercent}, UnitRatio, 0.85},
+		}
+
+		for _, tt := range tests {
+			got, err := tt.from.In(tt.to)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got.Value, 1e-4, "%s in %s", tt.from, tt.to)
+			assert.Equal(t, tt.to, got.Unit)
+		}
+	})
+
+	t.Run("rejects unknown and mismatched units", func(t *testing.T) {
+		_, err := Quantity{1, UnitMeters}.In(UnitKnots)
+		assert.EqualError(t, err, `cannot convert length "m" to speed "kn"`)
+
+		_, err = Quantity{1, "furlong"}.In(UnitMeters)
+		assert.Error(t, err)
+
+		_, err = Quantity{1, UnitMeters}.In("furlong")
+		assert.Error(t, err)
+
+		_, err = Quantity{Value: 1}.In(UnitRatio)
+		assert.EqualError(t, err, `unknown unit ""`)
+		assert.Equal(t, Dimension(""), Unit("").Dimension())
+	})
+
+	t.Run("formats with the unit symbol", func(t *testing.T) {
+		assert.Equal(t, "12.5 kn", Quantity{12.5, UnitKnots}.String())
+		assert.Equal(t, "18°C", Quantity{18, UnitCelsius}.String())
+		assert.Equal(t, "270°", Quantity{270, UnitDegrees}.String())
+		assert.Equal(t, "85%", Quantity{85, UnitPercent}.String())
+		assert.Equal(t, "0.4", Quantity{0.4, UnitRatio}.String())
+	})
+}
+
+func TestVariableUnit(t *testing.T) {
+	tests := map[string]Unit{
+		"windSpeed":         UnitMetersPerSecond,
+		"windDirection":     UnitDegrees,
+		"waveHeight":        UnitMeters,
+		"swellPeriod":       UnitSeconds,
+		"waterTemperature":  UnitCelsius,
+		"pressure":          UnitHectopascals,
+		"visibility":        UnitKilometers,
+		"humidity":          UnitPercent,
+		"iceCover":          UnitRatio,
+		"currentDirection":  UnitDegrees,
+		"windWaveDirection": UnitDegrees,
+	}
+
+	for name, want := range tests {
+		unit, ok := VariableUnit(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, unit, name)
+	}
+
+	_, ok := VariableUnit("unknown")
+	assert.False(t, ok)
+}
+
+func TestUnitProfile(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	points := &Points{
+		Hours: []Hour{{
+			Time:           &now,
+			WindSpeed:      &WeatherSourceValues{ICON: float64Ptr(10), NOAA: float64Ptr(5)},
+			WaveHeight:     &WeatherSourceValues{StormGlass: float64Ptr(2)},
+			AirTemperature: &WeatherSourceValues{StormGlass: float64Ptr(20)},
+			WindDirection:  &WeatherSourceValues{StormGlass: float64Ptr(270)},
+		}},
+		Meta: Meta{Cost: 1},
+	}
+
+	t.Run("returns quantities in the API unit", func(t *testing.T) {
+		q, ok := points.Hours[0].Quantity("windSpeed", SourceNOAA)
+		require.True(t, ok)
+		assert.Equal(t, Quantity{5, UnitMetersPerSecond}, q)
+
+		_, ok = points.Hours[0].Quantity("windSpeed", SourceSMHI)
+		assert.False(t, ok)
+	})
+
+	t.Run("converts points to the profile units", func(t *testing.T) {
+		converted, err := ImperialUnits().Convert(points)
+		require.NoError(t, err)
+		require.Len(t, converted.Hours, 1)
+
+		assert.Equal(t, points.Meta, converted.Meta)
+
+		hour := converted.Hours[0]
+		assert.Equal(t, now, hour.Time)
+
+		assert.Equal(t, map[string]Unit{
+			"windSpeed":      UnitMilesPerHour,
+			"waveHeight":     UnitFeet,
+			"airTemperature": UnitFahrenheit,
+			"windDirection":  UnitDegrees,
+		}, converted.Units)
+
+		for name, values := range hour.Values {
+			for source, q := range values {
+				assert.Equal(t, converted.Units[name], q.Unit, "%s of %s", name, source)
+			}
+		}
+
+		assert.Len(t, hour.Values, 4)
+		assert.Len(t, hour.Values["windSpeed"], 2)
+		assert.Equal(t, Quantity{270, UnitDegrees}, hour.Values["windDirection"][SourceStormGlass])
+		assert.InDelta(t, 22.3694, hour.Values["windSpeed"][SourceICON].Value, 1e-4)
+		assert.InDelta(t, 11.1847, hour.Values["windSpeed"][SourceNOAA].Value, 1e-4)
+		assert.InDelta(t, 6.5617, hour.Values["waveHeight"][SourceStormGlass].Value, 1e-4)
+		assert.InDelta(t, 68, hour.Values["airTemperature"][SourceStormGlass].Value, 1e-9)
+
+		q, ok := hour.Quantity("waveHeight", SourceStormGlass)
+		require.True(t, ok)
+		assert.Equal(t, UnitFeet, q.Unit)
+		assert.InDelta(t, 6.5617, q.Value, 1e-4)
+
+		_, ok = hour.Quantity("swellHeight", SourceStormGlass)
+		assert.False(t, ok)
+
+		assert.Equal(t, 10.0, *points.Hours[0].WindSpeed.ICON, "expected the points to be left unchanged")
+	})
+
+	t.Run("leaves empty profile units unchanged", func(t *testing.T) {
+		converted, err := UnitProfile{Speed: UnitKnots}.Convert(points)
+		require.NoError(t, err)
+
+		hour := converted.Hours[0]
+		assert.InDelta(t, 19.4384, hour.Values["windSpeed"][SourceICON].Value, 1e-4)
+		assert.Equal(t, Quantity{2, UnitMeters}, hour.Values["waveHeight"][SourceStormGlass])
+		assert.Equal(t, UnitMeters, converted.Units["waveHeight"])
+	})
+
+	t.Run("rejects units of another dimension", func(t *testing.T) {
+		_, err := UnitProfile{Speed: UnitFeet}.Convert(points)
+		assert.Error(t, err)
+	})
+}
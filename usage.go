@@ -0,0 +1,130 @@
+package stormglass
+
+import (
+	"sync"
+	"time"
+)
+
+// Usage represents the account usage observed by a Client. Requests and Cost count
+// the successful requests made by the client itself, DailyQuota and RequestCount
+// are the last values reported by the API in the response meta data.
+type Usage struct {
+	Requests     int       `json:"requests"`
+	Cost         int       `json:"cost"`
+	DailyQuota   int       `json:"dailyQuota"`
+	RequestCount int       `json:"requestCount"`
+	ResetAt      time.Time `json:"resetAt"`
+}
+
+// Remaining returns the number of requests left in the daily quota, or -1 when the
+// quota is not known yet.
+func (u Usage) Remaining() int {
+	if u.DailyQuota <= 0 {
+		return -1
+	}
+
+	if u.RequestCount >= u.DailyQuota {
+		return 0
+	}
+
+	return u.DailyQuota - u.RequestCount
+}
+
+// Fraction returns the used fraction of the daily quota, or 0 when the quota is
+// not known yet.
+func (u Usage) Fraction() float64 {
+	if u.DailyQuota <= 0 {
+		return 0
+	}
+
+	return float64(u.RequestCount) / float64(u.DailyQuota)
+}
+
+// Usage returns a snapshot of the usage observed by the client for the current day.
+func (c *Client) Usage() Usage {
+	return c.usage.snapshot(time.Now())
+}
+
+// metaResponse is implemented by responses carrying request meta data.
+type metaResponse interface {
+	meta() Meta
+}
+
+func (p *Points) meta() Meta          { return p.Meta }
+func (p *BioPoints) meta() Meta       { return p.Meta }
+func (p *SolarPoints) meta() Meta     { return p.Meta }
+func (p *AstronomyPoints) meta() Meta { return p.Meta }
+func (p *ExtremesPoints) meta() Meta  { return p.Meta.Meta }
+func (p *SeaLevelPoints) meta() Meta  { return p.Meta.Meta }
+func (p *TideStations) meta() Meta    { return p.Meta }
+func (p *ElevationPoint) meta() Meta  { return p.Meta.Meta }
+func (p *RawResponse) meta() Meta     { return p.Meta }
+
+// recordUsage updates the usage ledger from a response and notifies
+// OnUsageThreshold when the used fraction of the quota crosses UsageThreshold.
+func (c *Client) recordUsage(v interface{}) {
+	var m Meta
+	if r, ok := v.(metaResponse); ok {
+		m = r.meta()
+	}
+
+	usage, crossed := c.usage.record(m, c.UsageThreshold, time.Now())
+	if crossed && c.OnUsageThreshold != nil {
+		c.OnUsageThreshold(usage)
+	}
+}
+
+// usageLedger is a concurrency safe running account of the client usage which is
+// reset at UTC midnight, when the Stormglass daily quota resets.
+type usageLedger struct {
+	mu       sync.Mutex
+	usage    Usage
+	notified bool
+}
+
+func (l *usageLedger) snapshot(now time.Time) Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.resetIfExpired(now)
+
+	return l.usage
+}
+
+func (l *usageLedger) record(m Meta, threshold float64, now time.Time) (Usage, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.resetIfExpired(now)
+
+	l.usage.Requests++
+	l.usage.Cost += m.Cost
+
+	if m.DailyQuota > 0 {
+		l.usage.DailyQuota = m.DailyQuota
+	}
+
+	if m.RequestCount > 0 {
+		l.usage.RequestCount = m.RequestCount
+	}
+
+	crossed := false
+	if threshold > 0 && !l.notified && l.usage.DailyQuota > 0 && l.usage.Fraction() >= threshold {
+		l.notified = true
+		crossed = true
+	}
+
+	return l.usage, crossed
+}
+
+func (l *usageLedger) resetIfExpired(now time.Time) {
+	if !l.usage.ResetAt.IsZero() && now.Before(l.usage.ResetAt) {
+		return
+	}
+
+	y, m, d := now.UTC().Date()
+	l.usage = Usage{
+		ResetAt: time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC),
+	}
+	l.notified = false
+}
@@ -0,0 +1,133 @@
+package stormglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	t.Run("remaining and fraction with unknown quota", func(t *testing.T) {
+		u := Usage{RequestCount: 3}
+
+		assert.Equal(t, -1, u.Remaining())
+		assert.Equal(t, 0.0, u.Fraction())
+	})
+
+	t.Run("remaining and fraction", func(t *testing.T) {
+		u := Usage{RequestCount: 3, DailyQuota: 10}
+
+		assert.Equal(t, 7, u.Remaining())
+		assert.Equal(t, 0.3, u.Fraction())
+	})
+
+	t.Run("remaining with exceeded quota", func(t *testing.T) {
+		u := Usage{RequestCount: 12, DailyQuota: 10}
+
+		assert.Equal(t, 0, u.Remaining())
+	})
+}
+
+func TestUsageLedger(t *testing.T) {
+	day := time.Date(2022, 6, 1, 15, 0, 0, 0, time.UTC)
+
+	t.Run("records meta and computes reset time", func(t *testing.T) {
+		var l usageLedger
+
+		u, crossed := l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 1}, 0, day)
+		assert.False(t, crossed)
+
+		u, crossed = l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 2}, 0, day)
+		assert.False(t, crossed)
+		assert.Equal(t, 2, u.Requests)
+		assert.Equal(t, 2, u.Cost)
+		assert.Equal(t, 10, u.DailyQuota)
+		assert.Equal(t, 2, u.RequestCount)
+		assert.Equal(t, time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC), u.ResetAt)
+	})
+
+	t.Run("resets at utc midnight", func(t *testing.T) {
+		var l usageLedger
+
+		l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 9}, 0, day)
+
+		u := l.snapshot(day.Add(9 * time.Hour))
+		assert.Equal(t, 0, u.Requests)
+		assert.Equal(t, 0, u.RequestCount)
+		assert.Equal(t, time.Date(2022, 6, 3, 0, 0, 0, 0, time.UTC), u.ResetAt)
+	})
+
+	t.Run("threshold is crossed once per day", func(t *testing.T) {
+		var l usageLedger
+
+		_, crossed := l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 8}, 0.9, day)
+		assert.False(t, crossed)
+
+		_, crossed = l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 9}, 0.9, day)
+		assert.True(t, crossed)
+
+		_, crossed = l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 10}, 0.9, day)
+		assert.False(t, crossed)
+
+		_, crossed = l.record(Meta{Cost: 1, DailyQuota: 10, RequestCount: 9}, 0.9, day.Add(24*time.Hour))
+		assert.True(t, crossed)
+	})
+}
+
+func TestClient_Usage(t *testing.T) {
+	var testKey = "testkey123"
+
+	t.Run("usage is updated from every response", func(t *testing.T) {
+		var (
+			mu    sync.Mutex
+			count int
+		)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			count++
+			body := fmt.Sprintf(`{"meta": {"cost": 1, "dailyQuota": 10, "requestCount": %d}}`, count)
+			mu.Unlock()
+
+			if _, err := fmt.Fprint(w, body); err != nil {
+				t.Error(err)
+			}
+		}))
+		defer ts.Close()
+
+		var notified []Usage
+
+		c := NewClient(testKey)
+		c.BaseURL = ts.URL
+		c.HTTPClient = ts.Client()
+		c.UsageThreshold = 0.5
+		c.OnUsageThreshold = func(u Usage) {
+			notified = append(notified, u)
+		}
+
+		ctx := context.Background()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.GetElevationPoint(ctx, ElevationPointRequestOptions{})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		u := c.Usage()
+		assert.Equal(t, 6, u.Requests)
+		assert.Equal(t, 6, u.Cost)
+		assert.Equal(t, 10, u.DailyQuota)
+		assert.Len(t, notified, 1)
+	})
+}
@@ -2,15 +2,10 @@ package stormglass
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
-	"sort"
 	"strings"
 	"time"
-	"unicode"
-	"unicode/utf8"
 )
 
 // Points represents a Point request response.
@@ -40,7 +35,7 @@ func (s WeatherSourcesOptions) toList() []string {
 	}
 
 	if s.DWD {
-		sources = append(sources, "dwt")
+		sources = append(sources, "dwd")
 	}
 
 	if s.NOAA {
@@ -150,7 +145,8 @@ type Hour struct {
 	WindWavePeriod          *WeatherSourceValues `json:"windWavePeriod,omitempty"`
 }
 
-// WeatherParamsOptions holds optional parameters.
+// WeatherParamsOptions holds optional parameters. Params are sent under their
+// JSON names, by default the field names with a lowercase first letter.
 type WeatherParamsOptions struct {
 	Time                    bool
 	AirTemperature          bool
@@ -182,62 +178,34 @@ type WeatherParamsOptions struct {
 	WaveHeight              bool
 	WavePeriod              bool
 	WindDirection           bool
-	WindDirection1000Hpa    bool
-	WindDirection100M       bool
-	WindDirection200Hpa     bool
-	WindDirection20M        bool
-	WindDirection30M        bool
-	WindDirection40M        bool
-	WindDirection500Hpa     bool
-	WindDirection50M        bool
-	WindDirection800Hpa     bool
-	WindDirection80M        bool
+	WindDirection1000Hpa    bool `json:"windDirection1000hpa"`
+	WindDirection100M       bool `json:"windDirection100m"`
+	WindDirection200Hpa     bool `json:"windDirection200hpa"`
+	WindDirection20M        bool `json:"windDirection20m"`
+	WindDirection30M        bool `json:"windDirection30m"`
+	WindDirection40M        bool `json:"windDirection40m"`
+	WindDirection500Hpa     bool `json:"windDirection500hpa"`
+	WindDirection50M        bool `json:"windDirection50m"`
+	WindDirection800Hpa     bool `json:"windDirection800hpa"`
+	WindDirection80M        bool `json:"windDirection80m"`
 	WindSpeed               bool
-	WindSpeed1000Hpa        bool
-	WindSpeed100M           bool
-	WindSpeed200Hpa         bool
-	WindSpeed20M            bool
-	WindSpeed30M            bool
-	WindSpeed40M            bool
-	WindSpeed500Hpa         bool
-	WindSpeed50M            bool
-	WindSpeed800Hpa         bool
-	WindSpeed80M            bool
+	WindSpeed1000Hpa        bool `json:"windSpeed1000hpa"`
+	WindSpeed100M           bool `json:"windSpeed100m"`
+	WindSpeed200Hpa         bool `json:"windSpeed200hpa"`
+	WindSpeed20M            bool `json:"windSpeed20m"`
+	WindSpeed30M            bool `json:"windSpeed30m"`
+	WindSpeed40M            bool `json:"windSpeed40m"`
+	WindSpeed500Hpa         bool `json:"windSpeed500hpa"`
+	WindSpeed50M            bool `json:"windSpeed50m"`
+	WindSpeed800Hpa         bool `json:"windSpeed800hpa"`
+	WindSpeed80M            bool `json:"windSpeed80m"`
 	WindWaveDirection       bool
 	WindWaveHeight          bool
 	WindWavePeriod          bool
 }
 
 func (p WeatherParamsOptions) toList() []string {
-	var params []string
-
-	b, _ := json.Marshal(&p)
-	var m map[string]bool
-	_ = json.Unmarshal(b, &m)
-
-	for k, v := range m {
-		if v {
-			params = append(params, firstToLower(k))
-		}
-	}
-
-	sort.Slice(params, func(i, j int) bool {
-		return params[i] < params[j]
-	})
-
-	return params
-}
-
-func firstToLower(s string) string {
-	r, size := utf8.DecodeRuneInString(s)
-	if r == utf8.RuneError && size <= 1 {
-		return s
-	}
-	lc := unicode.ToLower(r)
-	if r == lc {
-		return s
-	}
-	return string(lc) + s[size:]
+	return paramsToList(p)
 }
 
 // PointsRequestOptions for available query parameters.
@@ -249,16 +217,6 @@ type PointsRequestOptions struct {
 
 // GetPoint sends a Point request https://docs.stormglass.io/#/weather?id=point-request.
 func (c *Client) GetPoint(ctx context.Context, options PointsRequestOptions) (*Points, error) {
-	path, err := url.JoinPath(c.BaseURL, "weather", "point")
-	if err != nil {
-		return nil, err
-	}
-
-	u, err := url.Parse(path)
-	if err != nil {
-		return nil, err
-	}
-
 	values := url.Values{}
 
 	values.Add("lat", fmt.Sprintf("%f", options.Lat))
@@ -278,21 +236,17 @@ func (c *Client) GetPoint(ctx context.Context, options PointsRequestOptions) (*P
 	}
 
 	sources := options.Source.toList()
-	if len(sources) > 0 {
-		values.Add("source", strings.Join(sources, ","))
+	if len(sources) == 0 {
+		sources = c.DefaultSources.toList()
 	}
 
-	u.RawQuery = values.Encode()
-
-	req, err := http.NewRequest("GET", u.String(), http.NoBody)
-	if err != nil {
-		return nil, err
+	if len(sources) > 0 {
+		values.Add("source", strings.Join(sources, ","))
 	}
 
-	req = req.WithContext(ctx)
 	res := Points{}
 
-	if err = c.sendRequest(req, &res); err != nil {
+	if err := c.get(ctx, EndpointWeatherPoint, options, values, &res); err != nil {
 		return nil, err
 	}
 
@@ -41,9 +41,15 @@ func TestParamOptionsToList(t *testing.T) {
 
 		var expected []string
 		// this assumes naming conventions for fields names
-		// as camel case matches of expected values
+		// as camel case matches of expected values, unless
+		// tagged with the API name
 		for i := 0; i < e.NumField(); i++ {
-			expected = append(expected, lcFirstLetter(e.Type().Field(i).Name))
+			name := e.Type().Field(i).Tag.Get("json")
+			if name == "" {
+				name = lcFirstLetter(e.Type().Field(i).Name)
+			}
+
+			expected = append(expected, name)
 		}
 
 		s := func(a []string) {
@@ -59,6 +65,26 @@ func TestParamOptionsToList(t *testing.T) {
 	})
 }
 
+func TestParamOptionsMatchHour(t *testing.T) {
+	fields := map[string]bool{}
+	for _, f := range hourFields() {
+		fields[f.name] = true
+	}
+
+	options := WeatherParamsOptions{}
+	e := reflect.ValueOf(&options).Elem()
+
+	for i := 0; i < e.NumField(); i++ {
+		e.Field(i).SetBool(true)
+	}
+
+	for _, param := range options.toList() {
+		if param != "time" {
+			assert.True(t, fields[param], "expected param %q to be a field of Hour", param)
+		}
+	}
+}
+
 func TestSourceOptionsToList(t *testing.T) {
 	t.Run("with no options", func(t *testing.T) {
 		var options WeatherSourcesOptions
@@ -90,7 +116,7 @@ func TestSourceOptionsToList(t *testing.T) {
 
 		expected := []string{
 			"icon",
-			"dwt",
+			"dwd",
 			"noaa",
 			"meteo",
 			"meto",